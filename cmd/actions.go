@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+// githubActions reports results through the workflow commands and files GitHub Actions provides
+type githubActions struct {
+	output      services.Output
+	summaryPath string
+	outputPath  string
+}
+
+func newGitHubActions(output services.Output) *githubActions {
+	return &githubActions{
+		output:      output,
+		summaryPath: os.Getenv("GITHUB_STEP_SUMMARY"),
+		outputPath:  os.Getenv("GITHUB_OUTPUT"),
+	}
+}
+
+// annotateFailures emits a warning annotation per failed repository, calling out authentication problems
+func (a *githubActions) annotateFailures(failures []repoFailure) {
+	for _, failure := range failures {
+		title := "Failed to fetch " + failure.Repo
+		message := failure.Err.Error()
+		if services.ErrorTypeOf(failure.Err) == services.ErrorTypeAuth {
+			title = "Authentication failed for " + failure.Repo
+			message += " (check the token passed as GH_TOKEN and its scopes)"
+		}
+		a.output.Printf("::warning title=%s::%s\n", escapeActionsProperty(title), escapeActionsData(message))
+	}
+}
+
+// writeSummary appends Markdown to the job's step summary
+func (a *githubActions) writeSummary(markdown string) error {
+	if a.summaryPath == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(a.summaryPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(markdown + "\n"); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// setOutputs appends step outputs, using the heredoc form so values may span lines
+func (a *githubActions) setOutputs(outputs [][2]string) error {
+	if a.outputPath == "" {
+		return nil
+	}
+
+	var b strings.Builder
+	for _, output := range outputs {
+		delimiter, err := actionsDelimiter()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s<<%s\n%s\n%s\n", output[0], delimiter, output[1], delimiter)
+	}
+
+	f, err := os.OpenFile(a.outputPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func actionsDelimiter() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "ghadelimiter_" + hex.EncodeToString(buf), nil
+}
+
+func escapeActionsData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeActionsProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// reportStatus publishes a status run to the step summary and outputs
+func (a *githubActions) reportStatus(cycle *statusCycle) error {
+	a.annotateFailures(cycle.Failures)
+
+	var b strings.Builder
+	b.WriteString("## 📈 OSS Watch status\n\n")
+
+	changed := cycle.Run.Changed()
+	if len(changed) == 0 {
+		b.WriteString("No new activity since last check.\n")
+	}
+	for _, repo := range changed {
+		fmt.Fprintf(&b, "### [%s](https://github.com/%s)\n\n", repo.Repo, repo.Repo)
+		for _, line := range repo.ChangeLines() {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+		for _, event := range repo.Activity {
+			if event.URL != "" {
+				fmt.Fprintf(&b, "- [#%d %s](%s)\n", event.Number, event.Title, event.URL)
+			}
+		}
+		b.WriteString("\n")
+	}
+	writeActionsFailures(&b, cycle.Failures)
+
+	if err := a.writeSummary(b.String()); err != nil {
+		return err
+	}
+
+	totalStars := 0
+	changedRepos := []string{}
+	for _, repo := range cycle.Run.Repos {
+		if repo.Stats != nil {
+			totalStars += repo.Stats.Stars
+		}
+		if repo.Summary.HasChanges {
+			changedRepos = append(changedRepos, repo.Repo)
+		}
+	}
+
+	reposJSON, err := json.Marshal(cycle.Run.Repos)
+	if err != nil {
+		return err
+	}
+	changedJSON, err := json.Marshal(changedRepos)
+	if err != nil {
+		return err
+	}
+
+	return a.setOutputs([][2]string{
+		{"has_changes", strconv.FormatBool(cycle.HasChanges)},
+		{"changed_repos", string(changedJSON)},
+		{"failed_count", strconv.Itoa(len(cycle.Failures))},
+		{"total_stars", strconv.Itoa(totalStars)},
+		{"repos", string(reposJSON)},
+	})
+}
+
+// reportDashboard publishes dashboard totals as a Markdown table and outputs
+func (a *githubActions) reportDashboard(data *dashboardData) error {
+	a.annotateFailures(data.Failures)
+
+	var b strings.Builder
+	b.WriteString("## 📊 OSS Watch dashboard\n\n")
+	b.WriteString("| Repository | ⭐ Stars | 🐛 Issues | 🔀 Pull Requests | 🍴 Forks | 🚦 CI |\n")
+	b.WriteString("|---|---:|---:|---:|---:|---|\n")
+	for _, repo := range data.Repos {
+		_, ci, ok := dashboardLine("ci", repo.Events)
+		if !ok {
+			ci = "—"
+		}
+		fmt.Fprintf(&b, "| [%s](https://github.com/%s) | %d | %d | %d | %d | %s |\n",
+			repo.Config.Repo, repo.Config.Repo, repo.Stats.Stars, repo.Stats.Issues, repo.Stats.PullRequests, repo.Stats.Forks, ci)
+	}
+	fmt.Fprintf(&b, "| **Total** | **%d** | **%d** | **%d** | **%d** | |\n\n",
+		data.Totals.Stars, data.Totals.Issues, data.Totals.PRs, data.Totals.Forks)
+	writeActionsFailures(&b, data.Failures)
+
+	if err := a.writeSummary(b.String()); err != nil {
+		return err
+	}
+
+	stats := make([]services.RepoStats, len(data.Repos))
+	for i, repo := range data.Repos {
+		stats[i] = repo.Stats
+	}
+	reposJSON, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+
+	return a.setOutputs([][2]string{
+		{"failed_count", strconv.Itoa(len(data.Failures))},
+		{"total_stars", strconv.Itoa(data.Totals.Stars)},
+		{"repos", string(reposJSON)},
+	})
+}
+
+func writeActionsFailures(b *strings.Builder, failures []repoFailure) {
+	if len(failures) == 0 {
+		return
+	}
+
+	b.WriteString("### ⚠️ Errors\n\n")
+	for _, failure := range failures {
+		fmt.Fprintf(b, "- `%s`: %v\n", failure.Repo, failure.Err)
+	}
+	b.WriteString("\n")
+}
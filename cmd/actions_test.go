@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGitHubActions_ReportStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+
+	dir := t.TempDir()
+	t.Setenv("GITHUB_STEP_SUMMARY", filepath.Join(dir, "summary.md"))
+	t.Setenv("GITHUB_OUTPUT", filepath.Join(dir, "output"))
+	actions := newGitHubActions(mockOutput)
+
+	authErr := services.NewAPIError("Bad credentials", 401, "owner/private", nil)
+	cycle := &statusCycle{
+		Repos:      2,
+		HasChanges: true,
+		Failures:   []repoFailure{{Repo: "owner/private", Err: authErr}},
+		Run: &services.RunResult{CheckedAt: time.Now(), Repos: []services.RepoRunResult{
+			{
+				Repo:    "owner/repo",
+				Events:  []string{"stars"},
+				Stats:   &services.RepoStats{Stars: 42},
+				State:   map[string]services.EventState{"stars": {Count: 42}},
+				Summary: services.EventSummary{Changes: map[string]int{"stars": 2}, HasChanges: true},
+			},
+			{Repo: "owner/private", Error: authErr.Error(), ErrorType: services.ErrorTypeAuth},
+		}},
+	}
+
+	mockOutput.EXPECT().Printf("::warning title=%s::%s\n", "Authentication failed for owner/private", gomock.Any())
+
+	if err := actions.reportStatus(cycle); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	summary, _ := os.ReadFile(filepath.Join(dir, "summary.md"))
+	if !strings.Contains(string(summary), "- ⭐ +2 stars (42 total)") || !strings.Contains(string(summary), "`owner/private`") {
+		t.Errorf("Unexpected step summary:\n%s", summary)
+	}
+
+	output, _ := os.ReadFile(filepath.Join(dir, "output"))
+	outputs := parseActionsOutputs(t, string(output))
+	if outputs["has_changes"] != "true" || outputs["total_stars"] != "42" || outputs["changed_repos"] != `["owner/repo"]` {
+		t.Errorf("Unexpected outputs %v", outputs)
+	}
+	if !strings.HasPrefix(outputs["repos"], `[{"repo":"owner/repo"`) {
+		t.Errorf("Expected per-repo JSON output, got %q", outputs["repos"])
+	}
+}
+
+func TestEscapeActionsProperty(t *testing.T) {
+	if got := escapeActionsProperty("a:b,c\n100%"); got != "a%3Ab%2Cc%0A100%25" {
+		t.Errorf("Unexpected escape %q", got)
+	}
+}
+
+// parseActionsOutputs reads the name<<delimiter form written to $GITHUB_OUTPUT
+func parseActionsOutputs(t *testing.T, content string) map[string]string {
+	t.Helper()
+
+	outputs := map[string]string{}
+	re := regexp.MustCompile(`(?s)(\w+)<<(ghadelimiter_\w+)\n(.*?)\n(ghadelimiter_\w+)\n`)
+	for _, match := range re.FindAllStringSubmatch(content, -1) {
+		if match[2] != match[4] {
+			t.Fatalf("Mismatched delimiters in %q", match[0])
+		}
+		outputs[match[1]] = match[3]
+	}
+	return outputs
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+// defaultEventsLimit bounds /events responses unless ?limit= asks for more
+const defaultEventsLimit = 100
+
+type apiRepo struct {
+	Repo         string             `json:"repo"`
+	Events       []string           `json:"events"`
+	Stars        int                `json:"stars"`
+	Issues       int                `json:"issues"`
+	PullRequests int                `json:"pull_requests"`
+	Forks        int                `json:"forks"`
+	UpdatedAt    time.Time          `json:"updated_at,omitzero"`
+	Error        string             `json:"error,omitempty"`
+	ErrorType    services.ErrorType `json:"error_type,omitempty"`
+}
+
+type apiSummary struct {
+	RefreshedAt  time.Time `json:"refreshed_at,omitzero"`
+	Stale        bool      `json:"stale"`
+	Repos        int       `json:"repos"`
+	Failed       int       `json:"failed"`
+	Stars        int       `json:"stars"`
+	Issues       int       `json:"issues"`
+	PullRequests int       `json:"pull_requests"`
+	Forks        int       `json:"forks"`
+}
+
+type apiHealth struct {
+	Status          string                     `json:"status"`
+	RefreshedAt     time.Time                  `json:"refreshed_at,omitzero"`
+	RefreshDuration string                     `json:"refresh_duration,omitempty"`
+	Refreshes       int                        `json:"refreshes"`
+	Errors          map[services.ErrorType]int `json:"errors"`
+	RateLimit       *rateLimitStatus           `json:"rate_limit,omitempty"`
+}
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// apiServer exposes the refresher's latest data as read-only JSON
+type apiServer struct {
+	cli       *CLI
+	refresher *statsRefresher
+}
+
+// registerAPI adds the JSON endpoints to mux, which may also serve metrics or webhooks
+func (c *CLI) registerAPI(mux *http.ServeMux, refresher *statsRefresher) {
+	api := &apiServer{cli: c, refresher: refresher}
+
+	mux.HandleFunc("GET /repos", api.handleRepos)
+	mux.HandleFunc("GET /repos/{owner}/{repo}", api.handleRepo)
+	mux.HandleFunc("GET /summary", api.handleSummary)
+	mux.HandleFunc("GET /events", api.handleEvents)
+	mux.HandleFunc("GET /health", api.handleHealth)
+}
+
+// snapshot returns the latest refreshed repositories, falling back to the cached counts
+// from the last status run until the first refresh completes
+func (a *apiServer) snapshot() ([]apiRepo, time.Time, bool, error) {
+	if latest := a.refresher.Latest(); latest != nil {
+		var repos []apiRepo
+		for _, repo := range latest.Data.Repos {
+			repos = append(repos, apiRepo{
+				Repo:         repo.Config.Repo,
+				Events:       repo.Config.Events,
+				Stars:        repo.Stats.Stars,
+				Issues:       repo.Stats.Issues,
+				PullRequests: repo.Stats.PullRequests,
+				Forks:        repo.Stats.Forks,
+				UpdatedAt:    repo.Stats.UpdatedAt,
+			})
+		}
+		for _, failure := range latest.Data.Failures {
+			repo := apiRepo{
+				Repo:      failure.Repo,
+				Error:     failure.Err.Error(),
+				ErrorType: services.ErrorTypeOf(failure.Err),
+			}
+			if config := latest.Config.GetRepo(failure.Repo); config != nil {
+				repo.Events = config.Events
+			}
+			repos = append(repos, repo)
+		}
+		return repos, latest.RefreshedAt, false, nil
+	}
+
+	config, err := a.cli.configService.Load()
+	if err != nil {
+		return nil, time.Time{}, true, err
+	}
+	cache, err := a.cli.cacheService.Load()
+	if err != nil {
+		return nil, time.Time{}, true, err
+	}
+
+	var repos []apiRepo
+	for _, repoConfig := range config.Repos {
+		state, ok := cache.Repos[repoConfig.Repo]
+		if !ok {
+			continue
+		}
+		repos = append(repos, apiRepo{
+			Repo:         repoConfig.Repo,
+			Events:       repoConfig.Events,
+			Stars:        state.Count("stars"),
+			Issues:       state.Count("issues"),
+			PullRequests: state.Count("pull_requests"),
+			Forks:        state.Count("forks"),
+			UpdatedAt:    state.LastUpdated,
+		})
+	}
+	return repos, cache.LastCheck, true, nil
+}
+
+func (a *apiServer) handleRepos(w http.ResponseWriter, r *http.Request) {
+	repos, _, _, err := a.snapshot()
+	if err != nil {
+		a.writeJSON(w, http.StatusInternalServerError, apiError{Error: err.Error()})
+		return
+	}
+	if repos == nil {
+		repos = []apiRepo{}
+	}
+	a.writeJSON(w, http.StatusOK, repos)
+}
+
+func (a *apiServer) handleRepo(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("owner") + "/" + r.PathValue("repo")
+
+	repos, _, _, err := a.snapshot()
+	if err != nil {
+		a.writeJSON(w, http.StatusInternalServerError, apiError{Error: err.Error()})
+		return
+	}
+
+	index := slices.IndexFunc(repos, func(repo apiRepo) bool { return repo.Repo == name })
+	if index < 0 {
+		a.writeJSON(w, http.StatusNotFound, apiError{Error: name + " is not watched"})
+		return
+	}
+	a.writeJSON(w, http.StatusOK, repos[index])
+}
+
+func (a *apiServer) handleSummary(w http.ResponseWriter, r *http.Request) {
+	repos, refreshedAt, stale, err := a.snapshot()
+	if err != nil {
+		a.writeJSON(w, http.StatusInternalServerError, apiError{Error: err.Error()})
+		return
+	}
+
+	summary := apiSummary{RefreshedAt: refreshedAt, Stale: stale, Repos: len(repos)}
+	for _, repo := range repos {
+		if repo.Error != "" {
+			summary.Failed++
+			continue
+		}
+		summary.Stars += repo.Stars
+		summary.Issues += repo.Issues
+		summary.PullRequests += repo.PullRequests
+		summary.Forks += repo.Forks
+	}
+	a.writeJSON(w, http.StatusOK, summary)
+}
+
+// handleEvents lists recorded events newest first, filtered by ?since= (RFC 3339 or a
+// duration such as 7d), ?repo= and ?limit=
+func (a *apiServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var since time.Time
+	if value := query.Get("since"); value != "" {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			since = t
+		} else if d, err := services.ParseDuration(value); err == nil {
+			since = time.Now().Add(-d)
+		} else {
+			a.writeJSON(w, http.StatusBadRequest, apiError{Error: "since must be an RFC 3339 time or a duration such as 7d"})
+			return
+		}
+	}
+
+	limit := defaultEventsLimit
+	if value := query.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			a.writeJSON(w, http.StatusBadRequest, apiError{Error: "limit must be a positive number"})
+			return
+		}
+		limit = n
+	}
+
+	history, err := a.cli.historyService.Load()
+	if err != nil {
+		a.writeJSON(w, http.StatusInternalServerError, apiError{Error: err.Error()})
+		return
+	}
+
+	events := []services.HistoryEvent{}
+	for i := len(history.Events) - 1; i >= 0 && len(events) < limit; i-- {
+		event := history.Events[i]
+		if event.Time.Before(since) {
+			continue
+		}
+		if repo := query.Get("repo"); repo != "" && event.Repo != repo {
+			continue
+		}
+		events = append(events, event)
+	}
+	slices.SortStableFunc(events, func(a, b services.HistoryEvent) int {
+		return b.Time.Compare(a.Time)
+	})
+
+	a.writeJSON(w, http.StatusOK, events)
+}
+
+func (a *apiServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	refreshes, errors := a.refresher.Counters()
+	health := apiHealth{Status: "starting", Refreshes: refreshes, Errors: errors}
+
+	if latest := a.refresher.Latest(); latest != nil {
+		health.Status = "ok"
+		health.RefreshedAt = latest.RefreshedAt
+		health.RefreshDuration = latest.Duration.Round(time.Millisecond).String()
+		if len(latest.Data.Failures) > 0 {
+			health.Status = "degraded"
+		}
+	}
+
+	if rateLimit := a.cli.rateLimit(); rateLimit != nil && !rateLimit.UpdatedAt.IsZero() {
+		health.RateLimit = &rateLimitStatus{
+			Limit:     rateLimit.Limit,
+			Remaining: rateLimit.Remaining,
+			Reset:     rateLimit.Reset,
+		}
+	}
+
+	a.writeJSON(w, http.StatusOK, health)
+}
+
+func (a *apiServer) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		a.cli.output.Printf("Error writing API response: %v\n", err)
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAPI_ServesLatestRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cli := &CLI{output: mock_services.NewMockOutput(ctrl)}
+
+	config := &services.Config{Repos: []services.RepoConfig{
+		{Repo: "owner/repo", Events: []string{"stars"}},
+		{Repo: "owner/gone", Events: []string{"forks"}},
+	}}
+	refresher := newStatsRefresher(cli, time.Minute)
+	refresher.latest = &refreshResult{
+		RefreshedAt: time.Now(),
+		Config:      config,
+		Data: &dashboardData{
+			Repos: []dashboardRepo{
+				{Config: config.Repos[0], Stats: services.RepoStats{Stars: 10, Issues: 2, Forks: 1}},
+			},
+			Failures: []repoFailure{{Repo: "owner/gone", Err: fmt.Errorf("boom")}},
+		},
+	}
+
+	mux := http.NewServeMux()
+	cli.registerAPI(mux, refresher)
+
+	get := func(path string, v any) int {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if v != nil {
+			if err := json.Unmarshal(recorder.Body.Bytes(), v); err != nil {
+				t.Fatalf("%s: expected JSON, got %q: %v", path, recorder.Body, err)
+			}
+		}
+		return recorder.Code
+	}
+
+	var repos []apiRepo
+	if code := get("/repos", &repos); code != http.StatusOK || len(repos) != 2 {
+		t.Errorf("Expected 2 repos, got %d: %+v", code, repos)
+	}
+
+	var repo apiRepo
+	if code := get("/repos/owner/repo", &repo); code != http.StatusOK || repo.Stars != 10 {
+		t.Errorf("Expected owner/repo with 10 stars, got %d: %+v", code, repo)
+	}
+	if code := get("/repos/owner/unknown", nil); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unwatched repo, got %d", code)
+	}
+
+	var summary apiSummary
+	get("/summary", &summary)
+	if summary.Repos != 2 || summary.Failed != 1 || summary.Stars != 10 || summary.Stale {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+
+	var health apiHealth
+	get("/health", &health)
+	if health.Status != "degraded" {
+		t.Errorf("Expected degraded health, got %+v", health)
+	}
+}
+
+func TestAPI_EventsSince(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
+	cli := &CLI{output: mock_services.NewMockOutput(ctrl), historyService: mockHistory}
+
+	now := time.Now()
+	mockHistory.EXPECT().Load().Return(&services.HistoryData{Events: []services.HistoryEvent{
+		{ID: "old", Repo: "owner/repo", Type: "stars", Time: now.Add(-10 * 24 * time.Hour)},
+		{ID: "recent", Repo: "owner/repo", Type: "stars", Time: now.Add(-time.Hour)},
+		{ID: "other", Repo: "owner/other", Type: "forks", Time: now.Add(-2 * time.Hour)},
+	}}, nil).Times(2)
+
+	mux := http.NewServeMux()
+	cli.registerAPI(mux, newStatsRefresher(cli, time.Minute))
+
+	var events []services.HistoryEvent
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/events?since=7d", nil))
+	_ = json.Unmarshal(recorder.Body.Bytes(), &events)
+	if len(events) != 2 || events[0].ID != "recent" || events[1].ID != "other" {
+		t.Errorf("Expected recent events newest first, got %+v", events)
+	}
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/events?repo=owner/other", nil))
+	_ = json.Unmarshal(recorder.Body.Bytes(), &events)
+	if len(events) != 1 || events[0].ID != "other" {
+		t.Errorf("Expected events for owner/other, got %+v", events)
+	}
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/events?since=yesterday", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid since, got %d", recorder.Code)
+	}
+}
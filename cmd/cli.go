@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -10,19 +11,33 @@ import (
 	"github.com/jackchuka/gh-oss-watch/services"
 )
 
+// Exit codes reported by Run, so scripts can branch on the outcome without parsing output
+const (
+	ExitOK             = 0
+	ExitError          = 1
+	ExitPartialFailure = 2
+	ExitChanges        = 10
+)
+
 type CLI struct {
-	configService services.ConfigService
-	cacheService  services.CacheService
-	githubService services.GitHubService
-	output        services.Output
+	configService  services.ConfigService
+	cacheService   services.CacheService
+	historyService services.HistoryService
+	githubService  services.GitHubService
+	output         services.Output
+	exitCode       int
+
+	// actions is set when running inside GitHub Actions
+	actions *githubActions
 }
 
-func NewCLI(configService services.ConfigService, cacheService services.CacheService, githubService services.GitHubService, output services.Output) *CLI {
+func NewCLI(configService services.ConfigService, cacheService services.CacheService, historyService services.HistoryService, githubService services.GitHubService, output services.Output) *CLI {
 	return &CLI{
-		configService: configService,
-		cacheService:  cacheService,
-		githubService: githubService,
-		output:        output,
+		configService:  configService,
+		cacheService:   cacheService,
+		historyService: historyService,
+		githubService:  githubService,
+		output:         output,
 	}
 }
 
@@ -34,6 +49,9 @@ func (c *CLI) Run(args []string) {
 
 	// Parse global flags and command
 	globalFlags, command, cmdArgs := c.parseGlobalFlags(args[1:])
+	if globalFlags.GitHubActions {
+		c.actions = newGitHubActions(c.output)
+	}
 
 	var err error
 
@@ -50,27 +68,62 @@ func (c *CLI) Run(args []string) {
 		err = c.handleStatusCommand(cmdArgs, globalFlags)
 	case "dashboard":
 		err = c.handleDashboardCommand(cmdArgs, globalFlags)
+	case "report":
+		err = c.handleReportCommand(cmdArgs, globalFlags)
+	case "digest":
+		err = c.handleDigestCommand(cmdArgs, globalFlags)
+	case "serve":
+		err = c.handleServeCommand(cmdArgs, globalFlags)
+	case "inbox":
+		err = c.handleInboxCommand(cmdArgs, globalFlags)
+	case "feed":
+		err = c.handleFeedCommand(cmdArgs)
+	case "watch":
+		err = c.handleWatchCommand(cmdArgs, globalFlags)
 	default:
 		c.output.Printf("Unknown command: %s\n", command)
 		c.printUsage()
-		os.Exit(1)
+		os.Exit(ExitError)
 	}
 
 	if err != nil {
 		c.output.Printf("Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(ExitError)
+	}
+
+	if c.exitCode != ExitOK {
+		os.Exit(c.exitCode)
 	}
 }
 
+// setOutcome records the exit code for a run over the given number of repositories.
+// Fetch failures take precedence over detected changes, and a run where every repository
+// failed is reported as an error.
+func (c *CLI) setOutcome(total int, failures []repoFailure, hasChanges bool) error {
+	switch {
+	case total > 0 && len(failures) == total:
+		return fmt.Errorf("failed to fetch stats for all %d repositories", total)
+	case len(failures) > 0:
+		c.exitCode = ExitPartialFailure
+	case hasChanges:
+		c.exitCode = ExitChanges
+	default:
+		c.exitCode = ExitOK
+	}
+	return nil
+}
+
 type GlobalFlags struct {
 	MaxConcurrent int
 	Timeout       int
+	GitHubActions bool
 }
 
 func (c *CLI) parseGlobalFlags(args []string) (GlobalFlags, string, []string) {
 	flags := GlobalFlags{
 		MaxConcurrent: 10,
 		Timeout:       30,
+		GitHubActions: os.Getenv("GITHUB_ACTIONS") == "true",
 	}
 
 	var command string
@@ -87,6 +140,8 @@ func (c *CLI) parseGlobalFlags(args []string) (GlobalFlags, string, []string) {
 			if val, err := strconv.Atoi(after); err == nil {
 				flags.Timeout = val
 			}
+		} else if arg == "--github-actions" {
+			flags.GitHubActions = true
 		} else if arg == "--max-concurrent" && i+1 < len(args) {
 			if val, err := strconv.Atoi(args[i+1]); err == nil {
 				flags.MaxConcurrent = val
@@ -107,11 +162,41 @@ func (c *CLI) parseGlobalFlags(args []string) (GlobalFlags, string, []string) {
 	return flags, command, cmdArgs
 }
 
-func (c *CLI) handleStatusCommand(_ []string, flags GlobalFlags) error {
+type statusOptions struct {
+	// Check reports activity without advancing the cache
+	Check bool
+	// All fetches every repository, ignoring per-repo intervals
+	All bool
+}
+
+func parseStatusArgs(args []string) (statusOptions, error) {
+	var opts statusOptions
+
+	for _, arg := range args {
+		switch arg {
+		case "--check":
+			opts.Check = true
+		case "--all":
+			opts.All = true
+		default:
+			return opts, fmt.Errorf("unknown status argument: %s", arg)
+		}
+	}
+
+	return opts, nil
+}
+
+func (c *CLI) handleStatusCommand(args []string, flags GlobalFlags) error {
+	opts, err := parseStatusArgs(args)
+	if err != nil {
+		c.output.Println("Usage: gh oss-watch status [--check] [--all]")
+		return err
+	}
+
 	c.githubService.SetMaxConcurrent(flags.MaxConcurrent)
 	c.githubService.SetTimeout(time.Duration(flags.Timeout) * time.Second)
 
-	return c.handleStatus()
+	return c.handleStatus(opts)
 }
 
 func (c *CLI) handleDashboardCommand(_ []string, flags GlobalFlags) error {
@@ -156,14 +241,48 @@ func (c *CLI) printUsage() {
 	c.output.Println("  add <repo> [events...]  Add repo to watch list")
 	c.output.Println("  set <repo> <events...>  Configure events for repo")
 	c.output.Println("  remove <repo>           Remove repo from watch list")
-	c.output.Println("  status                  Show new activity")
+	c.output.Println("  status [--check]        Show new activity (--check leaves the cache untouched, --all ignores intervals)")
 	c.output.Println("  dashboard               Show summary across all repos")
+	c.output.Println("  report --html <dir>     Write a static HTML report (--offline uses cached data)")
+	c.output.Println("  digest --markdown       Print a Markdown summary (--since 7d, --post <owner/repo>)")
+	c.output.Println("  serve --metrics <addr>  Expose Prometheus metrics (--textfile <path>, --interval 5m)")
+	c.output.Println("  serve --webhooks <addr> Receive GitHub webhooks on /webhook instead of polling")
+	c.output.Println("  serve --api <addr>      Serve read-only JSON: /repos, /summary, /events?since=7d, /health")
+	c.output.Println("  feed [--output <file>]  Render recorded activity as an Atom feed")
+	c.output.Println("  inbox [--mark-read]     List unread notifications for watched repos (--reason review_requested,mention)")
+	c.output.Println("  watch [--interval 15m]  Keep running status and notifiers (--health-addr <addr>, --health-file <path>)")
+	c.output.Println("")
+	c.output.Println("Events (add without events watches the defaults):")
+	for _, name := range services.EventSourceNames() {
+		source, _ := services.LookupEventSource(name)
+		note := ""
+		if slices.Contains(services.DefaultEvents(), name) {
+			note = " (default)"
+		}
+		c.output.Printf("  %-23s %s %s%s\n", name, source.Emoji(), source.Title(), note)
+	}
 	c.output.Println("")
 	c.output.Println("Performance Flags:")
 	c.output.Println("  --max-concurrent <n>    Max concurrent API requests (default: 10)")
 	c.output.Println("  --timeout <seconds>     Request timeout in seconds (default: 30)")
 	c.output.Println("")
+	c.output.Println("GitHub Actions:")
+	c.output.Println("  --github-actions        Write step summary, outputs and annotations (default when GITHUB_ACTIONS=true)")
+	c.output.Println("  GH_OSS_WATCH_STATE_DIR  Keep cache and history in this directory, e.g. for actions/cache")
+	c.output.Println("  GH_OSS_WATCH_CONFIG     Read the config from this file")
+	c.output.Println("")
+	c.output.Println("Exit Codes (status, dashboard):")
+	c.output.Println("  0   No changes")
+	c.output.Println("  1   Error, or every repository failed")
+	c.output.Println("  2   Some repositories failed")
+	c.output.Println("  10  Changes detected (status)")
+	c.output.Println("")
 	c.output.Println("Examples:")
 	c.output.Println("  gh oss-watch status --max-concurrent 20")
+	c.output.Println("  gh oss-watch status --check || echo \"exit $?\"")
 	c.output.Println("  gh oss-watch dashboard --timeout 60")
+	c.output.Println("  gh oss-watch report --html out/ --offline")
+	c.output.Println("  gh oss-watch digest --markdown --since 7d --post myorg/community")
+	c.output.Println("  gh oss-watch serve --metrics :9090 --interval 10m")
+	c.output.Println("  gh oss-watch watch --interval 15m --health-addr :8081")
 }
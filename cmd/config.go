@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"strings"
+
+	"github.com/jackchuka/gh-oss-watch/services"
 )
 
 func (c *CLI) handleConfigAdd(repo string, eventArgs []string) error {
@@ -11,7 +13,7 @@ func (c *CLI) handleConfigAdd(repo string, eventArgs []string) error {
 		return err
 	}
 
-	events := []string{"stars", "issues", "pull_requests", "forks"}
+	events := services.DefaultEvents()
 	if len(eventArgs) > 0 {
 		events = eventArgs
 	}
@@ -13,10 +13,11 @@ func TestHandleConfigAdd_Success(t *testing.T) {
 
 	mockConfig := mock_services.NewMockConfigService(ctrl)
 	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
 	mockGitHub := mock_services.NewMockGitHubService(ctrl)
 	mockOutput := mock_services.NewMockOutput(ctrl)
 
-	cli := NewCLI(mockConfig, mockCache, mockGitHub, mockOutput)
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
 
 	config := &services.Config{Repos: []services.RepoConfig{}}
 
@@ -46,10 +47,11 @@ func TestHandleConfigAdd_InvalidEvents(t *testing.T) {
 
 	mockConfig := mock_services.NewMockConfigService(ctrl)
 	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
 	mockGitHub := mock_services.NewMockGitHubService(ctrl)
 	mockOutput := mock_services.NewMockOutput(ctrl)
 
-	cli := NewCLI(mockConfig, mockCache, mockGitHub, mockOutput)
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
 
 	config := &services.Config{Repos: []services.RepoConfig{}}
 	mockConfig.EXPECT().Load().Return(config, nil)
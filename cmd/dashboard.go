@@ -1,38 +1,162 @@
 package cmd
 
 import (
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/jackchuka/gh-oss-watch/services"
 )
 
+type dashboardRepo struct {
+	Config services.RepoConfig
+	Stats  services.RepoStats
+
+	// Events holds the state of watched event sources that need more than the stats, such as releases
+	Events map[string]services.EventState
+}
+
+type dashboardTotals struct {
+	Stars  int
+	Issues int
+	PRs    int
+	Forks  int
+
+	// Events holds totals of dashboard event sources that add up across repositories, in registry order
+	Events []dashboardEventTotal
+}
+
+type dashboardEventTotal struct {
+	Event string
+	Label string
+	Count int
+}
+
+// dashboardData is the model shared by the console dashboard and the HTML report
+type dashboardData struct {
+	GeneratedAt time.Time
+	Repos       []dashboardRepo
+	Totals      dashboardTotals
+	Failures    []repoFailure
+}
+
+func (d *dashboardData) add(repoConfig services.RepoConfig, stats *services.RepoStats, events map[string]services.EventState) {
+	d.Repos = append(d.Repos, dashboardRepo{
+		Config: repoConfig,
+		Stats:  *stats,
+		Events: events,
+	})
+
+	d.Totals.Stars += stats.Stars
+	d.Totals.Issues += stats.Issues
+	d.Totals.PRs += stats.PullRequests
+	d.Totals.Forks += stats.Forks
+	for _, event := range watchedDashboardEvents(repoConfig) {
+		source, _ := services.LookupEventSource(event)
+		totaler, ok := source.(services.DashboardTotaler)
+		if !ok {
+			continue
+		}
+		label, _, ok := dashboardLine(event, events)
+		if !ok {
+			continue
+		}
+		count, ok := totaler.DashboardTotal(events[event])
+		if !ok {
+			continue
+		}
+
+		i := slices.IndexFunc(d.Totals.Events, func(total dashboardEventTotal) bool { return total.Event == event })
+		if i < 0 {
+			d.Totals.Events = append(d.Totals.Events, dashboardEventTotal{Event: event, Label: label})
+			i = len(d.Totals.Events) - 1
+		}
+		d.Totals.Events[i].Count += count
+	}
+}
+
 type dashboardProcessor struct {
-	output     services.Output
-	totalStats *struct {
-		Stars  int
-		Issues int
-		PRs    int
-		Forks  int
+	data        *dashboardData
+	fetchEvents func(stats *services.RepoStats, events []string, previous services.RepoState) (map[string]services.EventState, map[string]error)
+
+	// prefetched holds event states already fetched alongside the stats, keyed by repository
+	prefetched map[string]prefetchedEvents
+}
+
+// watchedDashboardEvents lists the events a repository watches whose sources have a dashboard line,
+// in registry order
+func watchedDashboardEvents(repoConfig services.RepoConfig) []string {
+	var watched []string
+	for _, event := range services.EventSourceNames() {
+		source, _ := services.LookupEventSource(event)
+		if _, ok := source.(services.DashboardSummarizer); ok && slices.Contains(repoConfig.Events, event) {
+			watched = append(watched, event)
+		}
+	}
+	return watched
+}
+
+// dashboardLine summarizes a repository's fetched event state for the dashboard, e.g. "CI" and
+// "✅ 4 workflows passing". It returns false when the event was not fetched or has nothing to show.
+func dashboardLine(event string, events map[string]services.EventState) (label, summary string, ok bool) {
+	state, ok := events[event]
+	if !ok {
+		return "", "", false
 	}
+	source, _ := services.LookupEventSource(event)
+	summarizer, ok := source.(services.DashboardSummarizer)
+	if !ok {
+		return "", "", false
+	}
+	return summarizer.DashboardLine(state)
+}
+
+func (d *dashboardProcessor) eventsRequest(repoConfig services.RepoConfig) services.RepoEventsRequest {
+	return services.RepoEventsRequest{Repo: repoConfig.Repo, Events: watchedDashboardEvents(repoConfig)}
+}
+
+func (d *dashboardProcessor) setPrefetched(repo string, events prefetchedEvents) {
+	if d.prefetched == nil {
+		d.prefetched = make(map[string]prefetchedEvents)
+	}
+	d.prefetched[repo] = events
 }
 
 func (d *dashboardProcessor) ProcessRepo(repoConfig services.RepoConfig, stats *services.RepoStats, index int) error {
-	d.output.Printf("\n📁 %s\n", repoConfig.Repo)
-	d.output.Printf("   ⭐ Stars: %d\n", stats.Stars)
-	d.output.Printf("   🐛 Issues: %d\n", stats.Issues)
-	d.output.Printf("   🔀 Pull Requests: %d\n", stats.PullRequests)
-	d.output.Printf("   🍴 Forks: %d\n", stats.Forks)
-	d.output.Printf("   📅 Last Updated: %s\n", stats.UpdatedAt.Format("2006-01-02 15:04"))
-	d.output.Printf("   📢 Watching: %s\n", strings.Join(repoConfig.Events, ", "))
-
-	d.totalStats.Stars += stats.Stars
-	d.totalStats.Issues += stats.Issues
-	d.totalStats.PRs += stats.PullRequests
-	d.totalStats.Forks += stats.Forks
+	watched := watchedDashboardEvents(repoConfig)
 
+	// Errors leave the event's line out rather than failing the whole repository
+	var events map[string]services.EventState
+	if prefetched, ok := d.prefetched[repoConfig.Repo]; ok {
+		events = prefetched.States
+		delete(d.prefetched, repoConfig.Repo)
+	} else if d.fetchEvents != nil && len(watched) > 0 {
+		events, _ = d.fetchEvents(stats, watched, services.RepoState{})
+	}
+
+	d.data.add(repoConfig, stats, events)
 	return nil
 }
 
+func (c *CLI) collectDashboard(config *services.Config) (*dashboardData, error) {
+	data := &dashboardData{GeneratedAt: time.Now()}
+
+	processor := &dashboardProcessor{
+		data: data,
+	}
+	if sources, ok := c.githubService.(services.EventGitHubService); ok {
+		processor.fetchEvents = sources.FetchEventStates
+	}
+
+	failures, err := c.processReposWithBatch(config, processor)
+	if err != nil {
+		return nil, err
+	}
+	data.Failures = failures
+
+	return data, nil
+}
+
 func (c *CLI) handleDashboard() error {
 	config, err := c.validateConfig()
 	if err != nil {
@@ -43,31 +167,52 @@ func (c *CLI) handleDashboard() error {
 		return nil
 	}
 
-	c.output.Println("📊 OSS Watch Dashboard")
-	c.output.Println("======================")
+	data, err := c.collectDashboard(config)
+	if err != nil {
+		return err
+	}
 
-	totalStats := struct {
-		Stars  int
-		Issues int
-		PRs    int
-		Forks  int
-	}{}
+	c.printDashboard(data)
 
-	processor := &dashboardProcessor{
-		output:     c.output,
-		totalStats: &totalStats,
+	if c.actions != nil {
+		if err := c.actions.reportDashboard(data); err != nil {
+			c.output.Printf("Warning: Error writing GitHub Actions results: %v\n", err)
+		}
 	}
 
-	err = c.processReposWithBatch(config, processor)
-	if err != nil {
-		return err
+	return c.setOutcome(len(config.Repos), data.Failures, false)
+}
+
+func (c *CLI) printDashboard(data *dashboardData) {
+	c.output.Println("📊 OSS Watch Dashboard")
+	c.output.Println("======================")
+
+	for _, repo := range data.Repos {
+		c.output.Printf("\n📁 %s\n", repo.Config.Repo)
+		c.output.Printf("   ⭐ Stars: %d\n", repo.Stats.Stars)
+		c.output.Printf("   🐛 Issues: %d\n", repo.Stats.Issues)
+		c.output.Printf("   🔀 Pull Requests: %d\n", repo.Stats.PullRequests)
+		c.output.Printf("   🍴 Forks: %d\n", repo.Stats.Forks)
+		c.output.Printf("   📅 Last Updated: %s\n", repo.Stats.UpdatedAt.Format("2006-01-02 15:04"))
+		for _, event := range watchedDashboardEvents(repo.Config) {
+			if label, summary, ok := dashboardLine(event, repo.Events); ok {
+				source, _ := services.LookupEventSource(event)
+				c.output.Printf("   %s %s: %s\n", source.Emoji(), label, summary)
+			}
+		}
+		c.output.Printf("   📢 Watching: %s\n", strings.Join(repo.Config.Events, ", "))
 	}
 
 	c.output.Println("\n📈 Total Across All Repos:")
-	c.output.Printf("   ⭐ Total Stars: %d\n", totalStats.Stars)
-	c.output.Printf("   🐛 Total Issues: %d\n", totalStats.Issues)
-	c.output.Printf("   🔀 Total PRs: %d\n", totalStats.PRs)
-	c.output.Printf("   🍴 Total Forks: %d\n", totalStats.Forks)
-
-	return nil
+	c.output.Printf("   ⭐ Total Stars: %d\n", data.Totals.Stars)
+	c.output.Printf("   🐛 Total Issues: %d\n", data.Totals.Issues)
+	c.output.Printf("   🔀 Total PRs: %d\n", data.Totals.PRs)
+	c.output.Printf("   🍴 Total Forks: %d\n", data.Totals.Forks)
+	for _, total := range data.Totals.Events {
+		if total.Count <= 0 {
+			continue
+		}
+		source, _ := services.LookupEventSource(total.Event)
+		c.output.Printf("   %s %s: %d\n", source.Emoji(), total.Label, total.Count)
+	}
 }
@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+const digestIssueLabel = "oss-watch-digest"
+
+type digestOptions struct {
+	Since            time.Duration
+	PostRepo         string
+	Title            string
+	NotableFollowers int
+}
+
+type digestRepo struct {
+	dashboardRepo
+	Baseline    services.RepoSnapshot
+	HasBaseline bool
+	Activity    *services.RepoActivity
+}
+
+type digest struct {
+	From  time.Time
+	To    time.Time
+	Repos []digestRepo
+	Data  *dashboardData
+}
+
+func parseDigestArgs(args []string) (digestOptions, error) {
+	opts := digestOptions{
+		Since:            7 * 24 * time.Hour,
+		Title:            "Weekly OSS digest",
+		NotableFollowers: 100,
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		var value string
+		name, inline, hasInline := strings.Cut(arg, "=")
+		switch name {
+		case "--markdown":
+			continue
+		case "--since", "--post", "--title", "--notable-followers":
+			if hasInline {
+				value = inline
+			} else if i+1 < len(args) {
+				value = args[i+1]
+				i++ // Skip next arg
+			} else {
+				return opts, fmt.Errorf("%s requires a value", name)
+			}
+		default:
+			return opts, fmt.Errorf("unknown digest argument: %s", arg)
+		}
+
+		switch name {
+		case "--since":
+			since, err := services.ParseDuration(value)
+			if err != nil {
+				return opts, err
+			}
+			opts.Since = since
+		case "--post":
+			if _, _, err := services.ParseRepoString(value); err != nil {
+				return opts, err
+			}
+			opts.PostRepo = value
+		case "--title":
+			opts.Title = value
+		case "--notable-followers":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return opts, fmt.Errorf("invalid follower count: %s", value)
+			}
+			opts.NotableFollowers = n
+		}
+	}
+
+	return opts, nil
+}
+
+func (c *CLI) handleDigestCommand(args []string, flags GlobalFlags) error {
+	opts, err := parseDigestArgs(args)
+	if err != nil {
+		c.output.Println("Usage: gh oss-watch digest --markdown [--since 7d] [--post <owner/repo>] [--title <title>]")
+		return err
+	}
+
+	c.githubService.SetMaxConcurrent(flags.MaxConcurrent)
+	c.githubService.SetTimeout(time.Duration(flags.Timeout) * time.Second)
+
+	return c.handleDigest(opts)
+}
+
+func (c *CLI) handleDigest(opts digestOptions) error {
+	config, err := c.validateConfig()
+	if err != nil {
+		return err
+	}
+
+	if len(config.Repos) == 0 {
+		return nil
+	}
+
+	history, err := c.historyService.Load()
+	if err != nil {
+		return err
+	}
+
+	data, err := c.collectDashboard(config)
+	if err != nil {
+		return err
+	}
+
+	d := &digest{
+		From: data.GeneratedAt.Add(-opts.Since),
+		To:   data.GeneratedAt,
+		Data: data,
+	}
+
+	activityService, canFetchActivity := c.githubService.(services.ActivityGitHubService)
+	for _, repo := range data.Repos {
+		entry := digestRepo{dashboardRepo: repo}
+		entry.Baseline, entry.HasBaseline = baselineSnapshot(history.Repos[repo.Config.Repo], d.From)
+
+		if canFetchActivity {
+			// Activity may be partial when only some of it could be fetched
+			activity, err := activityService.GetRepoActivity(repo.Stats.Owner, repo.Stats.Name, d.From)
+			if err != nil {
+				c.output.Printf("Error fetching activity for %s: %v\n", repo.Config.Repo, err)
+			}
+			entry.Activity = activity
+		}
+
+		d.Repos = append(d.Repos, entry)
+	}
+
+	markdown := renderDigestMarkdown(d, opts)
+
+	if c.actions != nil {
+		c.actions.annotateFailures(data.Failures)
+		if err := c.actions.writeSummary(markdown); err != nil {
+			c.output.Printf("Warning: Error writing GitHub Actions step summary: %v\n", err)
+		}
+	}
+
+	if opts.PostRepo == "" {
+		c.output.Println(markdown)
+		return nil
+	}
+
+	publisher, canPublish := c.githubService.(services.IssuePublisher)
+	if !canPublish {
+		return fmt.Errorf("posting digests is not supported by this GitHub service")
+	}
+
+	owner, repo, err := services.ParseRepoString(opts.PostRepo)
+	if err != nil {
+		return err
+	}
+
+	issue, err := publisher.UpsertPinnedIssue(owner, repo, services.IssueDraft{
+		Title: opts.Title,
+		Body:  markdown,
+		Label: digestIssueLabel,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.output.Printf("Posted digest to %s\n", issue.HTMLURL)
+	return nil
+}
+
+// baselineSnapshot returns the latest snapshot taken at or before the given time,
+// falling back to the earliest snapshot when history starts later
+func baselineSnapshot(snapshots []services.RepoSnapshot, at time.Time) (services.RepoSnapshot, bool) {
+	if len(snapshots) == 0 {
+		return services.RepoSnapshot{}, false
+	}
+
+	baseline := snapshots[0]
+	for _, snapshot := range snapshots {
+		if snapshot.Time.After(at) {
+			break
+		}
+		baseline = snapshot
+	}
+	return baseline, true
+}
+
+func renderDigestMarkdown(d *digest, opts digestOptions) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## 📊 %s: %s – %s\n\n", opts.Title, d.From.Format("2006-01-02"), d.To.Format("2006-01-02"))
+
+	b.WriteString("| Repository | ⭐ Stars | 🐛 Open issues | 🔀 Open PRs | 🍴 Forks |\n")
+	b.WriteString("|---|---:|---:|---:|---:|\n")
+	for _, repo := range d.Repos {
+		stats := repo.Stats
+		fmt.Fprintf(&b, "| [%s](https://github.com/%s) | %s | %s | %s | %s |\n",
+			repo.Config.Repo, repo.Config.Repo,
+			formatDigestCount(stats.Stars, repo.Baseline.Stars, repo.HasBaseline),
+			formatDigestCount(stats.Issues, repo.Baseline.Issues, repo.HasBaseline),
+			formatDigestCount(stats.PullRequests, repo.Baseline.PullRequests, repo.HasBaseline),
+			formatDigestCount(stats.Forks, repo.Baseline.Forks, repo.HasBaseline),
+		)
+	}
+	fmt.Fprintf(&b, "| **Total** | **%d** | **%d** | **%d** | **%d** |\n",
+		d.Data.Totals.Stars, d.Data.Totals.Issues, d.Data.Totals.PRs, d.Data.Totals.Forks)
+
+	var issues, pulls, releases, stargazers []string
+	for _, repo := range d.Repos {
+		if repo.Activity == nil {
+			continue
+		}
+		for _, issue := range repo.Activity.Issues {
+			issues = append(issues, fmt.Sprintf("- %s#%d %s (@%s)", repo.Config.Repo, issue.Number, issue.Title, issue.User.Login))
+		}
+		for _, pr := range repo.Activity.PullRequests {
+			pulls = append(pulls, fmt.Sprintf("- %s#%d %s (@%s)", repo.Config.Repo, pr.Number, pr.Title, pr.User.Login))
+		}
+		for _, release := range repo.Activity.Releases {
+			line := fmt.Sprintf("- %s [%s](%s)", repo.Config.Repo, release.TagName, release.HTMLURL)
+			if release.Name != "" && release.Name != release.TagName {
+				line += " " + release.Name
+			}
+			if release.Prerelease {
+				line += " _(pre-release)_"
+			}
+			releases = append(releases, line)
+		}
+		for _, stargazer := range repo.Activity.Stargazers {
+			if stargazer.User.Followers >= opts.NotableFollowers {
+				stargazers = append(stargazers, fmt.Sprintf("- [@%s](%s) (%d followers) starred %s",
+					stargazer.User.Login, stargazer.User.HTMLURL, stargazer.User.Followers, repo.Config.Repo))
+			}
+		}
+	}
+
+	writeDigestSection(&b, "🐛 New issues", issues)
+	writeDigestSection(&b, "🔀 New pull requests", pulls)
+	writeDigestSection(&b, "🚀 New releases", releases)
+	writeDigestSection(&b, "🌟 Notable stargazers", stargazers)
+
+	b.WriteString("\n<sub>Generated by gh-oss-watch</sub>\n")
+	return b.String()
+}
+
+func formatDigestCount(current, baseline int, hasBaseline bool) string {
+	if !hasBaseline {
+		return strconv.Itoa(current)
+	}
+	return fmt.Sprintf("%d (%+d)", current, current-baseline)
+}
+
+func writeDigestSection(b *strings.Builder, title string, lines []string) {
+	fmt.Fprintf(b, "\n### %s\n\n", title)
+	if len(lines) == 0 {
+		b.WriteString("_None_\n")
+		return
+	}
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+}
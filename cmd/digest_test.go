@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+func TestParseDigestArgs(t *testing.T) {
+	opts, err := parseDigestArgs([]string{"--markdown", "--since", "14d", "--post=owner/community"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if opts.Since != 14*24*time.Hour {
+		t.Errorf("Expected 14 days, got %v", opts.Since)
+	}
+	if opts.PostRepo != "owner/community" {
+		t.Errorf("Expected 'owner/community', got %s", opts.PostRepo)
+	}
+
+	if _, err := parseDigestArgs([]string{"--post", "invalid"}); err == nil {
+		t.Error("Expected error for invalid repo, got nil")
+	}
+}
+
+func TestRenderDigestMarkdown(t *testing.T) {
+	now := time.Now()
+	data := &dashboardData{GeneratedAt: now}
+	data.add(services.RepoConfig{Repo: "owner/repo"}, &services.RepoStats{Owner: "owner", Name: "repo", Stars: 120, Issues: 4}, nil)
+
+	d := &digest{
+		From: now.Add(-7 * 24 * time.Hour),
+		To:   now,
+		Data: data,
+		Repos: []digestRepo{{
+			dashboardRepo: data.Repos[0],
+			Baseline:      services.RepoSnapshot{Stars: 100, Issues: 6},
+			HasBaseline:   true,
+			Activity: &services.RepoActivity{
+				Issues: []services.IssueAPIData{{Number: 42, Title: "Crash on start", User: services.UserAPIData{Login: "alice"}}},
+				Stargazers: []services.StargazerAPIData{
+					{User: services.UserAPIData{Login: "famous", Followers: 5000}},
+					{User: services.UserAPIData{Login: "quiet", Followers: 3}},
+				},
+			},
+		}},
+	}
+
+	markdown := renderDigestMarkdown(d, digestOptions{Title: "Weekly OSS digest", NotableFollowers: 100})
+
+	for _, want := range []string{"120 (+20)", "4 (-2)", "- owner/repo#42 Crash on start (@alice)", "@famous", "### 🔀 New pull requests\n\n_None_"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected digest to contain %q\n%s", want, markdown)
+		}
+	}
+	if strings.Contains(markdown, "@quiet") {
+		t.Error("Expected stargazers below the follower threshold to be omitted")
+	}
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+// feedIDPrefix namespaces entry IDs so they stay stable across feed regenerations
+const feedIDPrefix = "tag:gh-oss-watch,2025:"
+
+type feedOptions struct {
+	Output string
+	Limit  int
+	Repo   string
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomPerson  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Updated  string       `xml:"updated"`
+	Link     atomLink     `xml:"link"`
+	Category atomCategory `xml:"category"`
+	Author   *atomPerson  `xml:"author,omitempty"`
+	Summary  string       `xml:"summary,omitempty"`
+}
+
+func parseFeedArgs(args []string) (feedOptions, error) {
+	opts := feedOptions{Limit: 100}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		var value string
+		name, inline, hasInline := strings.Cut(arg, "=")
+		if hasInline {
+			value = inline
+		} else if i+1 < len(args) {
+			value = args[i+1]
+			i++ // Skip next arg
+		} else {
+			return opts, fmt.Errorf("%s requires a value", name)
+		}
+
+		switch name {
+		case "--output", "-o":
+			opts.Output = value
+		case "--limit":
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit <= 0 {
+				return opts, fmt.Errorf("invalid limit: %s", value)
+			}
+			opts.Limit = limit
+		case "--repo":
+			opts.Repo = value
+		default:
+			return opts, fmt.Errorf("unknown feed argument: %s", arg)
+		}
+	}
+
+	return opts, nil
+}
+
+func (c *CLI) handleFeedCommand(args []string) error {
+	opts, err := parseFeedArgs(args)
+	if err != nil {
+		c.output.Println("Usage: gh oss-watch feed [--output <file>] [--limit 100] [--repo <owner/repo>]")
+		return err
+	}
+
+	return c.handleFeed(opts)
+}
+
+func (c *CLI) handleFeed(opts feedOptions) error {
+	config, err := c.configService.Load()
+	if err != nil {
+		return err
+	}
+
+	history, err := c.historyService.Load()
+	if err != nil {
+		return err
+	}
+
+	var events []services.HistoryEvent
+	for _, event := range history.Events {
+		if config.GetRepo(event.Repo) == nil {
+			continue
+		}
+		if opts.Repo != "" && event.Repo != opts.Repo {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	data, err := renderAtomFeed(events, opts.Limit, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if opts.Output == "" {
+		c.output.Println(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(opts.Output, data, 0644); err != nil {
+		return err
+	}
+	c.output.Printf("Wrote %d feed entries to %s\n", min(len(events), opts.Limit), opts.Output)
+	return nil
+}
+
+// renderAtomFeed renders the most recent events as an Atom document
+func renderAtomFeed(events []services.HistoryEvent, limit int, now time.Time) ([]byte, error) {
+	sorted := slices.Clone(events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.After(sorted[j].Time)
+	})
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	feed := atomFeed{
+		ID:      feedIDPrefix + "feed",
+		Title:   "gh-oss-watch activity",
+		Updated: now.UTC().Format(time.RFC3339),
+		Author:  atomPerson{Name: "gh-oss-watch"},
+	}
+	if len(sorted) > 0 {
+		feed.Updated = sorted[0].Time.UTC().Format(time.RFC3339)
+	}
+
+	for _, event := range sorted {
+		feed.Entries = append(feed.Entries, atomEntryForEvent(event))
+	}
+
+	data, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+func atomEntryForEvent(event services.HistoryEvent) atomEntry {
+	repoURL := "https://github.com/" + event.Repo
+
+	entry := atomEntry{
+		ID:       feedIDPrefix + event.ID,
+		Updated:  event.Time.UTC().Format(time.RFC3339),
+		Link:     atomLink{Href: event.URL, Rel: "alternate"},
+		Category: atomCategory{Term: event.Type},
+	}
+	if event.Author != "" {
+		entry.Author = &atomPerson{Name: event.Author, URI: "https://github.com/" + event.Author}
+	}
+
+	switch event.Type {
+	case services.HistoryEventIssue:
+		entry.Title = fmt.Sprintf("🐛 %s#%d: %s", event.Repo, event.Number, event.Title)
+	case services.HistoryEventPullRequest:
+		entry.Title = fmt.Sprintf("🔀 %s#%d: %s", event.Repo, event.Number, event.Title)
+	default:
+		if title, ok := services.DescribeItem(event); ok {
+			entry.Title = title
+			break
+		}
+
+		source, ok := services.LookupEventSource(event.Type)
+		if !ok || event.Count == 0 {
+			entry.Title = fmt.Sprintf("%s: %s", event.Repo, event.Type)
+			break
+		}
+		entry.Title = fmt.Sprintf("%s %s: %s", source.Emoji(), event.Repo, source.Render(services.EventChange{Count: event.Count}, nil))
+		if linker, ok := source.(services.EventSourceLinker); ok {
+			entry.Link.Href = linker.URL(event.Repo)
+		}
+	}
+
+	if entry.Link.Href == "" {
+		entry.Link.Href = repoURL
+	}
+
+	return entry
+}
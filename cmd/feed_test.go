@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+func TestRenderAtomFeed(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	history := &services.HistoryData{}
+
+	issue := services.IssueAPIData{
+		Number:    7,
+		Title:     "Support <templates>",
+		HTMLURL:   "https://github.com/owner/repo/issues/7",
+		User:      services.UserAPIData{Login: "alice"},
+		CreatedAt: now.Add(-time.Hour),
+	}
+	history.AddEvent(services.NewIssueEvent("owner/repo", issue))
+	history.AddEvent(services.NewCountEvent("owner/repo", "stars", 3, now))
+	history.AddEvent(services.NewReleaseEvent("owner/repo", services.ReleaseAPIData{
+		ID: 9, TagName: "v2.0.0-rc.1", Prerelease: true, PublishedAt: now.Add(-2 * time.Hour),
+	}))
+
+	// Recording the same issue again must not produce a duplicate entry
+	if history.AddEvent(services.NewIssueEvent("owner/repo", issue)) {
+		t.Error("Expected duplicate event to be ignored")
+	}
+
+	data, err := renderAtomFeed(history.Events, 10, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	feed := string(data)
+
+	for _, want := range []string{
+		"<id>tag:gh-oss-watch,2025:owner/repo/issue/7</id>",
+		"Support &lt;templates&gt;",
+		"<uri>https://github.com/alice</uri>",
+		`href="https://github.com/owner/repo/stargazers"`,
+		"🚀 owner/repo released v2.0.0-rc.1 (pre-release)",
+	} {
+		if !strings.Contains(feed, want) {
+			t.Errorf("Expected feed to contain %q\n%s", want, feed)
+		}
+	}
+
+	if strings.Index(feed, "+3 stars") > strings.Index(feed, "Support") {
+		t.Error("Expected entries to be ordered newest first")
+	}
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+// inboxReasons orders the notification reasons maintainers act on first; other reasons follow alphabetically
+var inboxReasons = []string{"review_requested", "mention", "assign", "ci_activity"}
+
+var inboxReasonEmoji = map[string]string{
+	"review_requested": "👀",
+	"mention":          "📣",
+	"team_mention":     "📣",
+	"assign":           "📌",
+	"ci_activity":      "🚦",
+}
+
+type inboxOptions struct {
+	// MarkRead marks every listed thread as read after printing it
+	MarkRead bool
+	// Reasons limits the inbox to these notification reasons
+	Reasons []string
+}
+
+func parseInboxArgs(args []string) (inboxOptions, error) {
+	var opts inboxOptions
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		name, value, hasValue := strings.Cut(arg, "=")
+		switch name {
+		case "--mark-read":
+			opts.MarkRead = true
+		case "--reason":
+			if !hasValue {
+				if i+1 >= len(args) {
+					return opts, fmt.Errorf("--reason requires a value")
+				}
+				value = args[i+1]
+				i++ // Skip next arg
+			}
+			opts.Reasons = append(opts.Reasons, strings.Split(value, ",")...)
+		default:
+			return opts, fmt.Errorf("unknown inbox argument: %s", arg)
+		}
+	}
+
+	return opts, nil
+}
+
+func (c *CLI) handleInboxCommand(args []string, flags GlobalFlags) error {
+	opts, err := parseInboxArgs(args)
+	if err != nil {
+		c.output.Println("Usage: gh oss-watch inbox [--reason review_requested,mention] [--mark-read]")
+		return err
+	}
+
+	c.githubService.SetMaxConcurrent(flags.MaxConcurrent)
+	c.githubService.SetTimeout(time.Duration(flags.Timeout) * time.Second)
+
+	return c.handleInbox(opts)
+}
+
+func (c *CLI) handleInbox(opts inboxOptions) error {
+	config, err := c.validateConfig()
+	if err != nil {
+		return err
+	}
+
+	if len(config.Repos) == 0 {
+		return nil
+	}
+
+	inbox, ok := c.githubService.(services.InboxGitHubService)
+	if !ok {
+		return fmt.Errorf("reading notifications is not supported by this GitHub service")
+	}
+
+	repos := make([]string, len(config.Repos))
+	for i, repoConfig := range config.Repos {
+		repos[i] = repoConfig.Repo
+	}
+
+	notifications, err := inbox.GetNotifications(repos)
+	if err != nil {
+		return err
+	}
+	if len(opts.Reasons) > 0 {
+		notifications = slices.DeleteFunc(notifications, func(n services.NotificationAPIData) bool {
+			return !slices.Contains(opts.Reasons, n.Reason)
+		})
+	}
+
+	if len(notifications) == 0 {
+		c.output.Println("📭 No unread notifications in watched repositories")
+		return nil
+	}
+
+	c.output.Printf("📥 Inbox: %d unread notifications in watched repositories\n", len(notifications))
+
+	// Repositories follow config order, so the inbox reads like status and dashboard
+	byRepo := make(map[string][]services.NotificationAPIData)
+	for _, notification := range notifications {
+		repo := strings.ToLower(notification.Repository.FullName)
+		byRepo[repo] = append(byRepo[repo], notification)
+	}
+	for _, repo := range repos {
+		threads := byRepo[strings.ToLower(repo)]
+		if len(threads) == 0 {
+			continue
+		}
+
+		c.output.Printf("\n%s:\n", repo)
+		byReason := make(map[string][]services.NotificationAPIData)
+		for _, thread := range threads {
+			byReason[thread.Reason] = append(byReason[thread.Reason], thread)
+		}
+		for _, reason := range sortInboxReasons(byReason) {
+			emoji, ok := inboxReasonEmoji[reason]
+			if !ok {
+				emoji = "🔔"
+			}
+			c.output.Printf("  %s %s (%d)\n", emoji, reason, len(byReason[reason]))
+			for _, thread := range byReason[reason] {
+				c.output.Printf("    - %s %s: %s\n", thread.Subject.Type, thread.Subject.Title, services.NotificationHTMLURL(thread))
+			}
+		}
+	}
+
+	if !opts.MarkRead {
+		return nil
+	}
+
+	ids := make([]string, len(notifications))
+	for i, notification := range notifications {
+		ids[i] = notification.ID
+	}
+
+	marked := 0
+	for i, err := range inbox.MarkNotificationsRead(ids) {
+		if err != nil {
+			c.output.Printf("Warning: Error marking %q as read: %v\n", notifications[i].Subject.Title, err)
+			continue
+		}
+		marked++
+	}
+	c.output.Printf("\n✅ Marked %d notifications as read\n", marked)
+
+	return nil
+}
+
+// sortInboxReasons lists the reasons present, with the ones in inboxReasons first
+func sortInboxReasons(byReason map[string][]services.NotificationAPIData) []string {
+	reasons := make([]string, 0, len(byReason))
+	for reason := range byReason {
+		reasons = append(reasons, reason)
+	}
+
+	rank := func(reason string) int {
+		if i := slices.Index(inboxReasons, reason); i >= 0 {
+			return i
+		}
+		return len(inboxReasons)
+	}
+	slices.SortFunc(reasons, func(a, b string) int {
+		if ra, rb := rank(a), rank(b); ra != rb {
+			return ra - rb
+		}
+		return strings.Compare(a, b)
+	})
+	return reasons
+}
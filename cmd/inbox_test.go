@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleInbox_GroupsByRepoAndReason(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockConfig := mock_services.NewMockConfigService(ctrl)
+	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
+	mockGitHub := mock_services.NewMockInboxGitHubService(ctrl)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
+
+	notification := func(id, repo, reason, subjectType, title, url string) services.NotificationAPIData {
+		n := services.NotificationAPIData{ID: id, Reason: reason, Unread: true}
+		n.Repository.FullName = repo
+		n.Subject.Type = subjectType
+		n.Subject.Title = title
+		n.Subject.URL = url
+		return n
+	}
+
+	config := &services.Config{Repos: []services.RepoConfig{{Repo: "owner/web"}, {Repo: "owner/api"}}}
+	mockConfig.EXPECT().Load().Return(config, nil)
+	mockGitHub.EXPECT().GetNotifications([]string{"owner/web", "owner/api"}).Return([]services.NotificationAPIData{
+		notification("1", "owner/api", "mention", "Issue", "Crash on start", "https://api.github.com/repos/owner/api/issues/3"),
+		notification("2", "owner/api", "review_requested", "PullRequest", "Add retries", "https://api.github.com/repos/owner/api/pulls/4"),
+		notification("3", "Owner/Web", "ci_activity", "CheckSuite", "CI failed", ""),
+		notification("4", "owner/api", "subscribed", "Issue", "Roadmap", "https://api.github.com/repos/owner/api/issues/1"),
+	}, nil)
+	mockGitHub.EXPECT().MarkNotificationsRead([]string{"1", "2", "3"}).Return([]error{nil, errors.New("boom"), nil})
+
+	var lines []string
+	mockOutput.EXPECT().Printf(gomock.Any(), gomock.Any()).DoAndReturn(func(format string, args ...any) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}).AnyTimes()
+
+	opts := inboxOptions{MarkRead: true, Reasons: []string{"review_requested", "mention", "ci_activity"}}
+	if err := cli.handleInbox(opts); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	output := strings.Join(lines, "")
+	for _, want := range []string{
+		"3 unread notifications",
+		"  🚦 ci_activity (1)\n    - CheckSuite CI failed: https://github.com/Owner/Web/actions\n",
+		"  👀 review_requested (1)\n    - PullRequest Add retries: https://github.com/owner/api/pull/4\n  📣 mention (1)\n",
+		"Warning: Error marking \"Add retries\" as read: boom",
+		"Marked 2 notifications as read",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, output)
+		}
+	}
+	if strings.Index(output, "owner/web:") > strings.Index(output, "owner/api:") {
+		t.Error("Expected repositories in config order")
+	}
+	if strings.Contains(output, "Roadmap") {
+		t.Error("Expected reasons not asked for to be left out")
+	}
+}
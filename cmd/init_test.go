@@ -14,10 +14,11 @@ func TestHandleInit_Success(t *testing.T) {
 
 	mockConfig := mock_services.NewMockConfigService(ctrl)
 	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
 	mockGitHub := mock_services.NewMockGitHubService(ctrl)
 	mockOutput := mock_services.NewMockOutput(ctrl)
 
-	cli := NewCLI(mockConfig, mockCache, mockGitHub, mockOutput)
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
 
 	// Set up expectations
 	mockConfig.EXPECT().Load().Return(&services.Config{Repos: []services.RepoConfig{}}, nil)
@@ -38,10 +39,11 @@ func TestHandleInit_LoadError(t *testing.T) {
 
 	mockConfig := mock_services.NewMockConfigService(ctrl)
 	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
 	mockGitHub := mock_services.NewMockGitHubService(ctrl)
 	mockOutput := mock_services.NewMockOutput(ctrl)
 
-	cli := NewCLI(mockConfig, mockCache, mockGitHub, mockOutput)
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
 
 	// Set up expectation for Load to return error
 	mockConfig.EXPECT().Load().Return(nil, fmt.Errorf("load failed"))
@@ -62,10 +64,11 @@ func TestHandleInit_SaveError(t *testing.T) {
 
 	mockConfig := mock_services.NewMockConfigService(ctrl)
 	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
 	mockGitHub := mock_services.NewMockGitHubService(ctrl)
 	mockOutput := mock_services.NewMockOutput(ctrl)
 
-	cli := NewCLI(mockConfig, mockCache, mockGitHub, mockOutput)
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
 
 	// Set up expectations
 	mockConfig.EXPECT().Load().Return(&services.Config{Repos: []services.RepoConfig{}}, nil)
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+type metricSample struct {
+	labels map[string]string
+	value  float64
+}
+
+type metric struct {
+	name    string
+	help    string
+	kind    string
+	samples []metricSample
+}
+
+// collectMetrics converts the refresher state into Prometheus metrics
+func collectMetrics(refresher *statsRefresher, rateLimit *services.RateLimitInfo) []metric {
+	stars := metric{name: "ossw_repo_stars", help: "Number of stargazers.", kind: "gauge"}
+	issues := metric{name: "ossw_repo_open_issues", help: "Number of open issues, including pull requests.", kind: "gauge"}
+	pulls := metric{name: "ossw_repo_open_pull_requests", help: "Number of open pull requests.", kind: "gauge"}
+	forks := metric{name: "ossw_repo_forks", help: "Number of forks.", kind: "gauge"}
+	up := metric{name: "ossw_repo_up", help: "Whether the last fetch for the repository succeeded.", kind: "gauge"}
+
+	refreshes, errorCounts := refresher.Counters()
+	metrics := []metric{}
+
+	if latest := refresher.Latest(); latest != nil {
+		for _, repo := range latest.Data.Repos {
+			labels := map[string]string{"repo": repo.Config.Repo}
+			stars.samples = append(stars.samples, metricSample{labels, float64(repo.Stats.Stars)})
+			issues.samples = append(issues.samples, metricSample{labels, float64(repo.Stats.Issues)})
+			pulls.samples = append(pulls.samples, metricSample{labels, float64(repo.Stats.PullRequests)})
+			forks.samples = append(forks.samples, metricSample{labels, float64(repo.Stats.Forks)})
+			up.samples = append(up.samples, metricSample{labels, 1})
+		}
+		for _, failure := range latest.Data.Failures {
+			up.samples = append(up.samples, metricSample{map[string]string{"repo": failure.Repo}, 0})
+		}
+
+		metrics = append(metrics, stars, issues, pulls, forks, up,
+			metric{
+				name:    "ossw_fetch_duration_seconds",
+				help:    "Duration of the last refresh across all repositories.",
+				kind:    "gauge",
+				samples: []metricSample{{nil, latest.Duration.Seconds()}},
+			},
+			metric{
+				name:    "ossw_last_refresh_timestamp_seconds",
+				help:    "Unix time of the last completed refresh.",
+				kind:    "gauge",
+				samples: []metricSample{{nil, float64(latest.RefreshedAt.Unix())}},
+			},
+		)
+	}
+
+	errorsMetric := metric{name: "ossw_fetch_errors_total", help: "Repository fetch errors by error type.", kind: "counter"}
+	errorTypes := make([]string, 0, len(errorCounts))
+	for errorType := range errorCounts {
+		errorTypes = append(errorTypes, string(errorType))
+	}
+	sort.Strings(errorTypes)
+	for _, errorType := range errorTypes {
+		count := errorCounts[services.ErrorType(errorType)]
+		errorsMetric.samples = append(errorsMetric.samples, metricSample{map[string]string{"type": errorType}, float64(count)})
+	}
+	metrics = append(metrics, errorsMetric, metric{
+		name:    "ossw_refreshes_total",
+		help:    "Number of completed refreshes.",
+		kind:    "counter",
+		samples: []metricSample{{nil, float64(refreshes)}},
+	})
+
+	if rateLimit != nil && !rateLimit.UpdatedAt.IsZero() {
+		metrics = append(metrics,
+			metric{
+				name:    "ossw_rate_limit_remaining",
+				help:    "GitHub API requests remaining in the current rate limit window.",
+				kind:    "gauge",
+				samples: []metricSample{{nil, float64(rateLimit.Remaining)}},
+			},
+			metric{
+				name:    "ossw_rate_limit_limit",
+				help:    "GitHub API requests allowed per rate limit window.",
+				kind:    "gauge",
+				samples: []metricSample{{nil, float64(rateLimit.Limit)}},
+			},
+			metric{
+				name:    "ossw_rate_limit_reset_timestamp_seconds",
+				help:    "Unix time at which the current rate limit window resets.",
+				kind:    "gauge",
+				samples: []metricSample{{nil, float64(rateLimit.Reset.Unix())}},
+			},
+		)
+	}
+
+	return metrics
+}
+
+// writeMetrics renders metrics in the Prometheus text exposition format
+func writeMetrics(w io.Writer, metrics []metric) error {
+	for _, m := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind); err != nil {
+			return err
+		}
+		for _, sample := range m.samples {
+			if _, err := fmt.Fprintf(w, "%s%s %g\n", m.name, formatMetricLabels(sample.labels), sample.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func formatMetricLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, key, escaper.Replace(labels[key]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (c *CLI) metricsHandler(refresher *statsRefresher) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := writeMetrics(w, collectMetrics(refresher, c.rateLimit())); err != nil {
+			c.output.Printf("Error writing metrics: %v\n", err)
+		}
+	})
+}
+
+// writeMetricsTextfile atomically writes metrics for the node_exporter textfile collector
+func (c *CLI) writeMetricsTextfile(path string, refresher *statsRefresher) error {
+	return writeFileAtomic(path, func(w io.Writer) error {
+		return writeMetrics(w, collectMetrics(refresher, c.rateLimit()))
+	})
+}
+
+func (c *CLI) rateLimit() *services.RateLimitInfo {
+	reporter, ok := c.githubService.(services.RateLimitReporter)
+	if !ok {
+		return nil
+	}
+	rateLimit := reporter.RateLimit()
+	return &rateLimit
+}
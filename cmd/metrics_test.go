@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	data := &dashboardData{GeneratedAt: time.Now()}
+	data.add(services.RepoConfig{Repo: "owner/repo"}, &services.RepoStats{Stars: 42, Issues: 3, PullRequests: 1, Forks: 7}, nil)
+	data.Failures = []repoFailure{{Repo: "owner/broken", Err: services.NewAPIError("access forbidden", 403, "owner/broken", errors.New("forbidden"))}}
+
+	refresher := newStatsRefresher(nil, time.Minute)
+	refresher.latest = &refreshResult{RefreshedAt: data.GeneratedAt, Duration: 1500 * time.Millisecond, Data: data}
+	refresher.refreshes = 1
+	refresher.errorCounts[services.ErrorTypeAuth] = 1
+
+	var b strings.Builder
+	err := writeMetrics(&b, collectMetrics(refresher, &services.RateLimitInfo{Limit: 5000, Remaining: 4321, UpdatedAt: time.Now()}))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, want := range []string{
+		"# TYPE ossw_repo_stars gauge\n",
+		`ossw_repo_stars{repo="owner/repo"} 42`,
+		`ossw_repo_up{repo="owner/broken"} 0`,
+		`ossw_fetch_errors_total{type="auth"} 1`,
+		"ossw_fetch_duration_seconds 1.5",
+		"ossw_rate_limit_remaining 4321",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("Expected metrics to contain %q\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+// notifyTimeout bounds how long a single notifier may take, including retries
+const notifyTimeout = 60 * time.Second
+
+// notify hands the run result to every configured notifier, reporting failures as warnings.
+// When rules are configured each notifier only receives the repositories its rules matched,
+// and rules are recorded in throttle once delivered so they stay quiet for their window.
+func (c *CLI) notify(config *services.Config, run *services.RunResult, throttle map[string]time.Time) {
+	notifiers, err := services.NewNotifiers(config)
+	if err != nil {
+		c.output.Printf("Warning: Error configuring notifiers: %v\n", err)
+		return
+	}
+
+	var engine *services.RuleEngine
+	routed := map[string]*services.RunResult{}
+	if len(config.Rules) > 0 {
+		engine, err = services.NewRuleEngine(config)
+		if err != nil {
+			c.output.Printf("Warning: Error configuring notification rules: %v\n", err)
+			return
+		}
+
+		names := make([]string, len(notifiers))
+		for i, notifier := range notifiers {
+			names[i] = notifier.Name()
+		}
+		if unknown := engine.UnknownNotifiers(names); len(unknown) > 0 {
+			c.output.Printf("Warning: Rules refer to unknown notifiers: %s\n", strings.Join(unknown, ", "))
+		}
+
+		routed = engine.Route(run, names, throttle)
+	}
+
+	for _, notifier := range notifiers {
+		notifierRun := run
+		if r, ok := routed[notifier.Name()]; ok {
+			notifierRun = r
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		err := notifier.Notify(ctx, notifierRun)
+		cancel()
+
+		if err != nil {
+			c.output.Printf("Warning: Notifier %s failed: %v\n", notifier.Name(), err)
+			continue
+		}
+		if engine != nil {
+			engine.RecordDelivery(notifierRun, throttle)
+		}
+	}
+}
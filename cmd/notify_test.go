@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNotify_WebhookSignsTemplatedPayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+	cli := &CLI{output: mockOutput}
+
+	t.Setenv("OSSW_TEST_SECRET", "s3cret")
+
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get("X-Signature-256")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	config := &services.Config{Notifiers: services.NotifiersConfig{
+		Webhooks: []services.WebhookConfig{{
+			Name:      "test",
+			URL:       server.URL,
+			SecretEnv: "OSSW_TEST_SECRET",
+			Template:  `{"repos": [{{range $i, $r := .Changed}}{{if $i}},{{end}}{{json $r.Repo}}{{end}}]}`,
+		}},
+	}}
+	run := &services.RunResult{CheckedAt: time.Now(), Repos: []services.RepoRunResult{
+		{Repo: "owner/quiet"},
+		{Repo: "owner/busy", Summary: services.EventSummary{Changes: map[string]int{"stars": 2}, HasChanges: true}},
+	}}
+
+	cli.notify(config, run, nil)
+
+	var payload struct {
+		Repos []string `json:"repos"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("Expected JSON payload, got %q: %v", body, err)
+	}
+	if len(payload.Repos) != 1 || payload.Repos[0] != "owner/busy" {
+		t.Errorf("Expected only changed repos, got %v", payload.Repos)
+	}
+	if signature != services.SignPayload("s3cret", body) {
+		t.Errorf("Unexpected signature %q", signature)
+	}
+}
+
+func TestNotify_WebhookRetriesServerErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+	cli := &CLI{output: mockOutput}
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := &services.Config{Notifiers: services.NotifiersConfig{
+		Webhooks: []services.WebhookConfig{{Name: "test", URL: server.URL}},
+	}}
+	run := &services.RunResult{Repos: []services.RepoRunResult{
+		{Repo: "owner/busy", Summary: services.EventSummary{Changes: map[string]int{"forks": 1}, HasChanges: true}},
+	}}
+
+	cli.notify(config, run, nil)
+
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestNotify_SlackRoutesByRepoAndGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+	cli := &CLI{output: mockOutput}
+
+	received := map[string][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message struct {
+			Channel string `json:"channel"`
+			Blocks  []struct {
+				Type string `json:"type"`
+				Text *struct {
+					Text string `json:"text"`
+				} `json:"text"`
+			} `json:"blocks"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&message)
+		for _, block := range message.Blocks {
+			if block.Type == "header" {
+				received[message.Channel] = append(received[message.Channel], block.Text.Text)
+			}
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OSSW_TEST_SLACK", server.URL)
+
+	config := &services.Config{
+		Groups: map[string][]string{"core": {"core/*"}},
+		Notifiers: services.NotifiersConfig{
+			Slack: []services.SlackConfig{{
+				Name:           "slack",
+				WebhookURLEnv:  "OSSW_TEST_SLACK",
+				DefaultChannel: "#oss-activity",
+				Routes: []services.SlackRoute{
+					{Groups: []string{"core"}, Channel: "#core-maintainers"},
+				},
+			}},
+		},
+	}
+	run := &services.RunResult{Repos: []services.RepoRunResult{
+		{Repo: "core/api", Events: []string{"stars"}, Summary: services.EventSummary{Changes: map[string]int{"stars": 1}, HasChanges: true}},
+		{Repo: "misc/tool", Events: []string{"forks"}, Summary: services.EventSummary{Changes: map[string]int{"forks": 1}, HasChanges: true}},
+		{Repo: "core/quiet", Events: []string{"stars"}},
+	}}
+
+	cli.notify(config, run, nil)
+
+	if got := received["#core-maintainers"]; len(got) != 1 || got[0] != "📈 core/api" {
+		t.Errorf("Expected core/api in #core-maintainers, got %v", got)
+	}
+	if got := received["#oss-activity"]; len(got) != 1 || got[0] != "📈 misc/tool" {
+		t.Errorf("Expected misc/tool in #oss-activity, got %v", got)
+	}
+}
+
+func TestNotify_EmailSendsMultipartDigest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+	cli := &CLI{output: mockOutput}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	received := make(chan string, 1)
+	go serveFakeSMTP(listener, received)
+
+	host, portStr, _ := net.SplitHostPort(listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	config := &services.Config{Notifiers: services.NotifiersConfig{
+		Email: []services.EmailConfig{{
+			Name:            "email",
+			Host:            host,
+			Port:            port,
+			From:            "watch@example.com",
+			To:              []string{"team@example.com"},
+			DisableStartTLS: true,
+		}},
+	}}
+	run := &services.RunResult{CheckedAt: time.Now(), Repos: []services.RepoRunResult{
+		{
+			Repo:    "owner/repo",
+			Events:  []string{"stars"},
+			Stats:   &services.RepoStats{Stars: 12},
+			State:   map[string]services.EventState{"stars": {Count: 12}},
+			Summary: services.EventSummary{Changes: map[string]int{"stars": 2}, HasChanges: true},
+		},
+	}}
+
+	cli.notify(config, run, nil)
+
+	select {
+	case message := <-received:
+		for _, want := range []string{"multipart/alternative", "text/plain", "text/html", "⭐ +2 stars (12 total)"} {
+			if !strings.Contains(message, want) {
+				t.Errorf("Expected message to contain %q", want)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for email")
+	}
+}
+
+func TestNotify_RulesRouteAndThrottle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+	cli := &CLI{output: mockOutput}
+
+	received := map[string][][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var run services.RunResult
+		_ = json.NewDecoder(r.Body).Decode(&run)
+		var repos []string
+		for _, repo := range run.Repos {
+			repos = append(repos, repo.Repo)
+		}
+		received[r.URL.Path] = append(received[r.URL.Path], repos)
+	}))
+	defer server.Close()
+
+	config := &services.Config{
+		Groups: map[string][]string{"core": {"core/*"}},
+		Notifiers: services.NotifiersConfig{Webhooks: []services.WebhookConfig{
+			{Name: "team", URL: server.URL + "/team"},
+			{Name: "everyone", URL: server.URL + "/everyone"},
+		}},
+		Rules: []services.RuleConfig{
+			{Name: "core-stars", When: "stars_delta >= 10 and repo in group:core", Notify: []string{"team"}, Throttle: "1h"},
+			{Name: "new-issues", When: "issues_delta > 0 && not (hour between 0 and 23 and repo == misc/*)", Notify: []string{"everyone"}},
+		},
+	}
+	run := &services.RunResult{CheckedAt: time.Now(), Repos: []services.RepoRunResult{
+		{Repo: "core/api", Summary: services.EventSummary{Changes: map[string]int{"stars": 12, "issues": 1}, HasChanges: true}},
+		{Repo: "core/small", Summary: services.EventSummary{Changes: map[string]int{"stars": 2}, HasChanges: true}},
+		{Repo: "misc/tool", Summary: services.EventSummary{Changes: map[string]int{"issues": 4}, HasChanges: true}},
+	}}
+	throttle := map[string]time.Time{}
+
+	cli.notify(config, run, throttle)
+
+	if got := received["/team"]; len(got) != 1 || strings.Join(got[0], ",") != "core/api" {
+		t.Errorf("Expected team to hear about core/api only, got %v", got)
+	}
+	if got := received["/everyone"]; len(got) != 1 || strings.Join(got[0], ",") != "core/api" {
+		t.Errorf("Expected everyone to hear about core/api only, got %v", got)
+	}
+	if _, ok := throttle["core-stars|core/api"]; !ok {
+		t.Errorf("Expected core-stars to be throttled for core/api, got %v", throttle)
+	}
+
+	run.CheckedAt = run.CheckedAt.Add(30 * time.Minute)
+	cli.notify(config, run, throttle)
+
+	if got := received["/team"]; len(got) != 1 {
+		t.Errorf("Expected core-stars to stay quiet within its throttle window, got %v", got)
+	}
+	if got := received["/everyone"]; len(got) != 2 {
+		t.Errorf("Expected unthrottled rule to fire again, got %v", got)
+	}
+}
+
+func TestNotify_FailedDeliveryIsNotThrottled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+	mockOutput.EXPECT().Printf("Warning: Notifier %s failed: %v\n", "team", gomock.Any())
+	cli := &CLI{output: mockOutput}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	config := &services.Config{
+		Notifiers: services.NotifiersConfig{Webhooks: []services.WebhookConfig{{Name: "team", URL: server.URL}}},
+		Rules:     []services.RuleConfig{{Name: "stars", When: "stars_delta > 0", Throttle: "1h"}},
+	}
+	run := &services.RunResult{CheckedAt: time.Now(), Repos: []services.RepoRunResult{
+		{Repo: "core/api", Summary: services.EventSummary{Changes: map[string]int{"stars": 12}, HasChanges: true}},
+	}}
+	throttle := map[string]time.Time{}
+
+	cli.notify(config, run, throttle)
+
+	if len(throttle) != 0 {
+		t.Errorf("Expected a failed delivery not to be throttled, got %v", throttle)
+	}
+}
+
+func TestNotify_RulesRejectInvalidCondition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+	cli := &CLI{output: mockOutput}
+
+	config := &services.Config{Rules: []services.RuleConfig{{Name: "bad", When: "stars_delta >>= ten"}}}
+
+	mockOutput.EXPECT().Printf("Warning: Error configuring notification rules: %v\n", gomock.Any())
+
+	cli.notify(config, &services.RunResult{}, nil)
+}
+
+func TestNotify_HookReceivesRunOnStdin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+	cli := &CLI{output: mockOutput}
+
+	dir := t.TempDir()
+	payloadFile := filepath.Join(dir, "payload.json")
+	envFile := filepath.Join(dir, "env.txt")
+
+	config := &services.Config{Hooks: services.HooksConfig{
+		OnChange: []services.HookConfig{{
+			Name:    "capture",
+			Command: services.HookCommand{"sh", "-c", `cat > "$0"; echo "$OSSW_EVENT $OSSW_CHANGED_REPOS $OSSW_NEW_STARS" > "$1"`, payloadFile, envFile},
+		}},
+		OnThreshold: []services.HookConfig{{
+			Name:       "big",
+			Command:    services.HookCommand{"sh", "-c", `touch "$0"`, filepath.Join(dir, "threshold")},
+			Thresholds: map[string]int{"stars": 10},
+		}},
+	}}
+	run := &services.RunResult{CheckedAt: time.Now(), Repos: []services.RepoRunResult{
+		{Repo: "owner/busy", Summary: services.EventSummary{Changes: map[string]int{"stars": 3}, HasChanges: true}},
+		{Repo: "owner/quiet"},
+	}}
+
+	cli.notify(config, run, nil)
+
+	data, err := os.ReadFile(payloadFile)
+	if err != nil {
+		t.Fatalf("Expected hook to write payload: %v", err)
+	}
+	var payload services.HookPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("Expected JSON payload, got %q: %v", data, err)
+	}
+	if payload.Event != services.HookOnChange || len(payload.Run.Repos) != 2 {
+		t.Errorf("Unexpected payload %+v", payload)
+	}
+
+	env, _ := os.ReadFile(envFile)
+	if got := strings.TrimSpace(string(env)); got != "on_change owner/busy 3" {
+		t.Errorf("Unexpected hook environment %q", got)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "threshold")); err == nil {
+		t.Error("Expected threshold hook not to run below its threshold")
+	}
+}
+
+func TestNotify_HookReportsExitCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+	cli := &CLI{output: mockOutput}
+
+	config := &services.Config{Hooks: services.HooksConfig{
+		OnError: []services.HookConfig{{Name: "fail", Command: services.HookCommand{"sh", "-c", "echo boom; exit 3"}}},
+	}}
+	run := &services.RunResult{Repos: []services.RepoRunResult{
+		{Repo: "owner/broken", Error: "not found"},
+	}}
+
+	mockOutput.EXPECT().Printf("Warning: Notifier %s failed: %v\n", "hooks", gomock.Any()).Do(func(format string, args ...any) {
+		if msg := fmt.Sprint(args[1]); !strings.Contains(msg, "exited with code 3: boom") {
+			t.Errorf("Unexpected hook error %q", msg)
+		}
+	})
+
+	cli.notify(config, run, nil)
+}
+
+// serveFakeSMTP accepts one connection, speaks just enough SMTP to receive a message and reports its data
+func serveFakeSMTP(listener net.Listener, received chan<- string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		switch strings.ToUpper(strings.SplitN(line, " ", 2)[0]) {
+		case "EHLO", "HELO":
+			_ = text.PrintfLine("250 localhost")
+		case "DATA":
+			_ = text.PrintfLine("354 go ahead")
+			data, _ := text.ReadDotLines()
+			received <- strings.Join(data, "\n")
+			_ = text.PrintfLine("250 queued")
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			return
+		default:
+			_ = text.PrintfLine("250 ok")
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+// refreshResult holds the outcome of a single background refresh
+type refreshResult struct {
+	RefreshedAt time.Time
+	Duration    time.Duration
+	Config      *services.Config
+	Data        *dashboardData
+}
+
+// statsRefresher periodically fetches stats for all configured repositories and keeps the latest result
+type statsRefresher struct {
+	cli      *CLI
+	interval time.Duration
+	onUpdate []func(*refreshResult)
+
+	mu          sync.RWMutex
+	latest      *refreshResult
+	refreshes   int
+	errorCounts map[services.ErrorType]int
+}
+
+func newStatsRefresher(cli *CLI, interval time.Duration) *statsRefresher {
+	return &statsRefresher{
+		cli:         cli,
+		interval:    interval,
+		errorCounts: make(map[services.ErrorType]int),
+	}
+}
+
+// Run refreshes immediately and then on every interval until the context is cancelled
+func (r *statsRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Refresh(); err != nil {
+			r.cli.output.Printf("Error refreshing stats: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh fetches stats once and stores the result
+func (r *statsRefresher) Refresh() error {
+	config, err := r.cli.configService.Load()
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	data, err := r.cli.collectDashboard(config)
+	if err != nil {
+		return err
+	}
+
+	result := &refreshResult{
+		RefreshedAt: start,
+		Duration:    time.Since(start),
+		Config:      config,
+		Data:        data,
+	}
+
+	r.mu.Lock()
+	r.latest = result
+	r.refreshes++
+	for _, failure := range data.Failures {
+		r.errorCounts[services.ErrorTypeOf(failure.Err)]++
+	}
+	r.mu.Unlock()
+
+	for _, fn := range r.onUpdate {
+		fn(result)
+	}
+
+	return nil
+}
+
+// Latest returns the most recent refresh result, or nil if no refresh has completed
+func (r *statsRefresher) Latest() *refreshResult {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.latest
+}
+
+// Counters returns the number of completed refreshes and fetch errors by type
+func (r *statsRefresher) Counters() (int, map[services.ErrorType]int) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[services.ErrorType]int, len(r.errorCounts))
+	for errorType, count := range r.errorCounts {
+		counts[errorType] = count
+	}
+	return r.refreshes, counts
+}
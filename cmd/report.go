@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+//go:embed templates/report.html
+var reportTemplates embed.FS
+
+// maxReportActivity caps the number of entries in the recent-activity list
+const maxReportActivity = 50
+
+type reportOptions struct {
+	HTMLDir string
+	Offline bool
+}
+
+type reportActivity struct {
+	Time time.Time
+	Repo string
+	Text string
+}
+
+type reportChart struct {
+	Title string
+	SVG   template.HTML
+}
+
+type reportRepo struct {
+	dashboardRepo
+	Page      string
+	Sparkline template.HTML
+	Charts    []reportChart
+	Activity  []reportActivity
+}
+
+type reportPage struct {
+	Title    string
+	Root     string
+	Data     *dashboardData
+	Repos    []reportRepo
+	Repo     *reportRepo
+	Activity []reportActivity
+	Offline  bool
+}
+
+func parseReportArgs(args []string) (reportOptions, error) {
+	var opts reportOptions
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if after, ok := strings.CutPrefix(arg, "--html="); ok {
+			opts.HTMLDir = after
+		} else if arg == "--html" && i+1 < len(args) {
+			opts.HTMLDir = args[i+1]
+			i++ // Skip next arg
+		} else if arg == "--offline" {
+			opts.Offline = true
+		} else {
+			return opts, fmt.Errorf("unknown report argument: %s", arg)
+		}
+	}
+
+	if opts.HTMLDir == "" {
+		return opts, fmt.Errorf("output directory required (--html <dir>)")
+	}
+
+	return opts, nil
+}
+
+func (c *CLI) handleReportCommand(args []string, flags GlobalFlags) error {
+	opts, err := parseReportArgs(args)
+	if err != nil {
+		c.output.Println("Usage: gh oss-watch report --html <dir> [--offline]")
+		return err
+	}
+
+	c.githubService.SetMaxConcurrent(flags.MaxConcurrent)
+	c.githubService.SetTimeout(time.Duration(flags.Timeout) * time.Second)
+
+	return c.handleReport(opts)
+}
+
+func (c *CLI) handleReport(opts reportOptions) error {
+	config, err := c.validateConfig()
+	if err != nil {
+		return err
+	}
+
+	if len(config.Repos) == 0 {
+		return nil
+	}
+
+	history, err := c.historyService.Load()
+	if err != nil {
+		return err
+	}
+
+	var data *dashboardData
+	if opts.Offline {
+		data, err = c.collectOfflineDashboard(config, history)
+	} else {
+		data, err = c.collectDashboard(config)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !opts.Offline {
+		for _, repo := range data.Repos {
+			history.Record(repo.Config.Repo, &repo.Stats, data.GeneratedAt)
+		}
+		if err := c.historyService.Save(history); err != nil {
+			c.output.Printf("Warning: Error saving history: %v\n", err)
+		}
+	}
+
+	if err := writeHTMLReport(opts.HTMLDir, data, history, opts.Offline); err != nil {
+		return err
+	}
+
+	c.output.Printf("Wrote HTML report for %d repositories to %s\n", len(data.Repos), opts.HTMLDir)
+	return nil
+}
+
+// collectOfflineDashboard builds the dashboard from cached state, falling back to recorded history
+func (c *CLI) collectOfflineDashboard(config *services.Config, history *services.HistoryData) (*dashboardData, error) {
+	cache, err := c.cacheService.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	data := &dashboardData{GeneratedAt: time.Now()}
+
+	for _, repoConfig := range config.Repos {
+		owner, repo, err := services.ParseRepoString(repoConfig.Repo)
+		if err != nil {
+			c.output.Printf("Error parsing repo %s: %v\n", repoConfig.Repo, err)
+			continue
+		}
+
+		stats := services.RepoStats{Name: repo, Owner: owner}
+		var events map[string]services.EventState
+		if state, ok := cache.Repos[repoConfig.Repo]; ok {
+			events = state.Events
+			stats.Stars = state.Count("stars")
+			stats.Issues = state.Count("issues")
+			stats.PullRequests = state.Count("pull_requests")
+			stats.Forks = state.Count("forks")
+			stats.UpdatedAt = state.LastUpdated
+		} else if snapshot, ok := history.Latest(repoConfig.Repo); ok {
+			stats.Stars = snapshot.Stars
+			stats.Issues = snapshot.Issues
+			stats.PullRequests = snapshot.PullRequests
+			stats.Forks = snapshot.Forks
+			stats.UpdatedAt = snapshot.Time
+		} else {
+			c.output.Printf("No cached data for %s, skipping\n", repoConfig.Repo)
+			continue
+		}
+
+		data.add(repoConfig, &stats, events)
+	}
+
+	return data, nil
+}
+
+func writeHTMLReport(dir string, data *dashboardData, history *services.HistoryData, offline bool) error {
+	tmpl, err := template.New("report.html").Funcs(template.FuncMap{
+		"formatTime": func(t time.Time) string {
+			if t.IsZero() {
+				return "never"
+			}
+			return t.Format("2006-01-02 15:04")
+		},
+	}).ParseFS(reportTemplates, "templates/report.html")
+	if err != nil {
+		return err
+	}
+
+	repos := make([]reportRepo, len(data.Repos))
+	var activity []reportActivity
+	for i, repo := range data.Repos {
+		snapshots := history.Repos[repo.Config.Repo]
+		series := historySeries(snapshots)
+
+		charts := make([]reportChart, len(series))
+		for j, s := range series {
+			charts[j] = reportChart{Title: s.Title, SVG: renderLineChart(s, 480, 180)}
+		}
+
+		repoActivity := historyActivity(repo.Config.Repo, snapshots)
+		repos[i] = reportRepo{
+			dashboardRepo: repo,
+			Page:          filepath.ToSlash(filepath.Join("repos", repo.Config.Repo+".html")),
+			Sparkline:     renderSparkline(series[0], 120, 24),
+			Charts:        charts,
+			Activity:      limitActivity(repoActivity),
+		}
+		activity = append(activity, repoActivity...)
+	}
+
+	index := reportPage{
+		Title:    "OSS Watch Dashboard",
+		Data:     data,
+		Repos:    repos,
+		Activity: limitActivity(activity),
+		Offline:  offline,
+	}
+	if err := renderReportPage(tmpl, "index", filepath.Join(dir, "index.html"), index); err != nil {
+		return err
+	}
+
+	for i := range repos {
+		page := reportPage{
+			Title:   repos[i].Config.Repo,
+			Root:    strings.Repeat("../", strings.Count(repos[i].Page, "/")),
+			Data:    data,
+			Repo:    &repos[i],
+			Offline: offline,
+		}
+		if err := renderReportPage(tmpl, "repo", filepath.Join(dir, filepath.FromSlash(repos[i].Page)), page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renderReportPage(tmpl *template.Template, name, path string, page reportPage) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return tmpl.ExecuteTemplate(f, name, page)
+}
+
+// historyActivity derives activity entries from the differences between consecutive snapshots
+func historyActivity(repo string, snapshots []services.RepoSnapshot) []reportActivity {
+	var activity []reportActivity
+
+	for i := 1; i < len(snapshots); i++ {
+		prev, cur := snapshots[i-1], snapshots[i]
+
+		var changes []string
+		for _, delta := range []struct {
+			value int
+			label string
+		}{
+			{cur.Stars - prev.Stars, "stars"},
+			{cur.Issues - prev.Issues, "open issues"},
+			{cur.PullRequests - prev.PullRequests, "open pull requests"},
+			{cur.Forks - prev.Forks, "forks"},
+		} {
+			if delta.value != 0 {
+				changes = append(changes, fmt.Sprintf("%+d %s", delta.value, delta.label))
+			}
+		}
+
+		if len(changes) > 0 {
+			activity = append(activity, reportActivity{
+				Time: cur.Time,
+				Repo: repo,
+				Text: strings.Join(changes, ", "),
+			})
+		}
+	}
+
+	return activity
+}
+
+func limitActivity(activity []reportActivity) []reportActivity {
+	sorted := make([]reportActivity, len(activity))
+	copy(sorted, activity)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.After(sorted[j].Time)
+	})
+
+	if len(sorted) > maxReportActivity {
+		sorted = sorted[:maxReportActivity]
+	}
+	return sorted
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+type chartPoint struct {
+	Time  time.Time
+	Value int
+}
+
+type chartSeries struct {
+	Title  string
+	Color  string
+	Points []chartPoint
+}
+
+func historySeries(snapshots []services.RepoSnapshot) []chartSeries {
+	series := []chartSeries{
+		{Title: "Stars", Color: "#e3b341"},
+		{Title: "Open Issues", Color: "#db6d28"},
+		{Title: "Open Pull Requests", Color: "#8957e5"},
+		{Title: "Forks", Color: "#2f81f7"},
+	}
+
+	for _, snapshot := range snapshots {
+		series[0].Points = append(series[0].Points, chartPoint{Time: snapshot.Time, Value: snapshot.Stars})
+		series[1].Points = append(series[1].Points, chartPoint{Time: snapshot.Time, Value: snapshot.Issues})
+		series[2].Points = append(series[2].Points, chartPoint{Time: snapshot.Time, Value: snapshot.PullRequests})
+		series[3].Points = append(series[3].Points, chartPoint{Time: snapshot.Time, Value: snapshot.Forks})
+	}
+
+	return series
+}
+
+// renderLineChart draws a series as an inline SVG line chart with min/max and date labels
+func renderLineChart(series chartSeries, width, height int) template.HTML {
+	const padLeft, padRight, padTop, padBottom = 48, 12, 12, 24
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" role="img" aria-label="%s">`,
+		width, height, width, height, html.EscapeString(series.Title))
+
+	if len(series.Points) < 2 {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" class="muted">Not enough history yet</text></svg>`, width/2, height/2)
+		return template.HTML(b.String())
+	}
+
+	minValue, maxValue := series.Points[0].Value, series.Points[0].Value
+	for _, p := range series.Points {
+		minValue = min(minValue, p.Value)
+		maxValue = max(maxValue, p.Value)
+	}
+	valueRange := float64(maxValue - minValue)
+	if valueRange == 0 {
+		valueRange = 1
+	}
+
+	start := series.Points[0].Time
+	end := series.Points[len(series.Points)-1].Time
+	timeRange := float64(end.Sub(start))
+	if timeRange == 0 {
+		timeRange = 1
+	}
+
+	plotWidth := float64(width - padLeft - padRight)
+	plotHeight := float64(height - padTop - padBottom)
+
+	coords := make([]string, len(series.Points))
+	for i, p := range series.Points {
+		x := float64(padLeft) + plotWidth*float64(p.Time.Sub(start))/timeRange
+		y := float64(padTop) + plotHeight - plotHeight*float64(p.Value-minValue)/valueRange
+		coords[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+	}
+
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" class="axis"/>`, padLeft, height-padBottom, width-padRight, height-padBottom)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" class="axis"/>`, padLeft, padTop, padLeft, height-padBottom)
+	fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2" points="%s"/>`, series.Color, strings.Join(coords, " "))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end" class="label">%d</text>`, padLeft-6, padTop+4, maxValue)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end" class="label">%d</text>`, padLeft-6, height-padBottom, minValue)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" class="label">%s</text>`, padLeft, height-6, start.Format("2006-01-02"))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end" class="label">%s</text>`, width-padRight, height-6, end.Format("2006-01-02"))
+	b.WriteString(`</svg>`)
+
+	return template.HTML(b.String())
+}
+
+// renderSparkline draws a compact SVG line without axes or labels
+func renderSparkline(series chartSeries, width, height int) template.HTML {
+	if len(series.Points) < 2 {
+		return ""
+	}
+
+	minValue, maxValue := series.Points[0].Value, series.Points[0].Value
+	for _, p := range series.Points {
+		minValue = min(minValue, p.Value)
+		maxValue = max(maxValue, p.Value)
+	}
+	valueRange := float64(maxValue - minValue)
+	if valueRange == 0 {
+		valueRange = 1
+	}
+
+	step := float64(width-2) / float64(len(series.Points)-1)
+	coords := make([]string, len(series.Points))
+	for i, p := range series.Points {
+		x := 1 + step*float64(i)
+		y := 1 + float64(height-2) - float64(height-2)*float64(p.Value-minValue)/valueRange
+		coords[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+	}
+
+	return template.HTML(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" aria-hidden="true"><polyline fill="none" stroke="%s" stroke-width="1.5" points="%s"/></svg>`,
+		width, height, width, height, series.Color, strings.Join(coords, " "),
+	))
+}
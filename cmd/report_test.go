@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseReportArgs(t *testing.T) {
+	opts, err := parseReportArgs([]string{"--html", "out/", "--offline"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if opts.HTMLDir != "out/" || !opts.Offline {
+		t.Errorf("Unexpected options: %+v", opts)
+	}
+
+	if _, err := parseReportArgs([]string{"--offline"}); err == nil {
+		t.Error("Expected error when --html is missing, got nil")
+	}
+}
+
+func TestHandleReport_Offline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockConfig := mock_services.NewMockConfigService(ctrl)
+	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
+	mockGitHub := mock_services.NewMockGitHubService(ctrl)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
+
+	now := time.Now()
+	history := &services.HistoryData{Repos: map[string][]services.RepoSnapshot{
+		"owner/repo": {
+			{Time: now.Add(-48 * time.Hour), Stars: 10, Issues: 2},
+			{Time: now.Add(-24 * time.Hour), Stars: 15, Issues: 3},
+		},
+	}}
+
+	mockConfig.EXPECT().Load().Return(&services.Config{Repos: []services.RepoConfig{
+		{Repo: "owner/repo", Events: []string{"stars"}},
+	}}, nil)
+	mockHistory.EXPECT().Load().Return(history, nil)
+	mockCache.EXPECT().Load().Return(&services.CacheData{Repos: map[string]services.RepoState{
+		"owner/repo": {Events: map[string]services.EventState{"stars": {Count: 15}, "issues": {Count: 3}}},
+	}}, nil)
+	mockOutput.EXPECT().Printf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	dir := t.TempDir()
+	if err := cli.handleReport(reportOptions{HTMLDir: dir, Offline: true}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatalf("Expected index.html to be written: %v", err)
+	}
+	for _, want := range []string{"owner/repo", "5 stars, &#43;1 open issues", "<svg"} {
+		if !strings.Contains(string(index), want) {
+			t.Errorf("Expected index.html to contain %q", want)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "repos", "owner", "repo.html")); err != nil {
+		t.Errorf("Expected repo page to be written: %v", err)
+	}
+}
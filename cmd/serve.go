@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+// defaultWebhookSecretEnv holds the webhook secret unless --webhook-secret-env names another variable
+const defaultWebhookSecretEnv = "GH_OSS_WATCH_WEBHOOK_SECRET"
+
+type serveOptions struct {
+	MetricsAddr      string
+	TextfilePath     string
+	Interval         time.Duration
+	WebhooksAddr     string
+	WebhookSecretEnv string
+	APIAddr          string
+}
+
+func parseServeArgs(args []string) (serveOptions, error) {
+	opts := serveOptions{
+		Interval:         5 * time.Minute,
+		WebhookSecretEnv: defaultWebhookSecretEnv,
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		var value string
+		name, inline, hasInline := strings.Cut(arg, "=")
+		if hasInline {
+			value = inline
+		} else if i+1 < len(args) {
+			value = args[i+1]
+			i++ // Skip next arg
+		} else {
+			return opts, fmt.Errorf("%s requires a value", name)
+		}
+
+		switch name {
+		case "--metrics":
+			opts.MetricsAddr = value
+		case "--textfile":
+			opts.TextfilePath = value
+		case "--api":
+			opts.APIAddr = value
+		case "--webhooks":
+			opts.WebhooksAddr = value
+		case "--webhook-secret-env":
+			opts.WebhookSecretEnv = value
+		case "--interval":
+			interval, err := services.ParseDuration(value)
+			if err != nil {
+				return opts, err
+			}
+			if interval <= 0 {
+				return opts, fmt.Errorf("interval must be positive")
+			}
+			opts.Interval = interval
+		default:
+			return opts, fmt.Errorf("unknown serve argument: %s", arg)
+		}
+	}
+
+	if opts.MetricsAddr == "" && opts.TextfilePath == "" && opts.WebhooksAddr == "" && opts.APIAddr == "" {
+		return opts, fmt.Errorf("nothing to serve (use --metrics <addr>, --textfile <path>, --webhooks <addr> or --api <addr>)")
+	}
+
+	return opts, nil
+}
+
+func (c *CLI) handleServeCommand(args []string, flags GlobalFlags) error {
+	opts, err := parseServeArgs(args)
+	if err != nil {
+		c.output.Println("Usage: gh oss-watch serve [--metrics <addr>] [--textfile <path>] [--interval 5m] [--webhooks <addr>] [--webhook-secret-env <name>] [--api <addr>]")
+		return err
+	}
+
+	c.githubService.SetMaxConcurrent(flags.MaxConcurrent)
+	c.githubService.SetTimeout(time.Duration(flags.Timeout) * time.Second)
+
+	return c.handleServe(opts)
+}
+
+func (c *CLI) handleServe(opts serveOptions) error {
+	refresher := newStatsRefresher(c, opts.Interval)
+
+	if opts.TextfilePath != "" {
+		refresher.onUpdate = append(refresher.onUpdate, func(*refreshResult) {
+			if err := c.writeMetricsTextfile(opts.TextfilePath, refresher); err != nil {
+				c.output.Printf("Error writing metrics textfile: %v\n", err)
+			}
+		})
+	}
+
+	// Without a listener there is nothing to keep running, so write the textfile once
+	if opts.MetricsAddr == "" && opts.WebhooksAddr == "" && opts.APIAddr == "" {
+		if err := refresher.Refresh(); err != nil {
+			return err
+		}
+		c.output.Printf("Wrote metrics to %s\n", opts.TextfilePath)
+		return nil
+	}
+
+	// Handlers are grouped by address so metrics and webhooks can share a port
+	muxes := map[string]*http.ServeMux{}
+	muxFor := func(addr string) *http.ServeMux {
+		if muxes[addr] == nil {
+			muxes[addr] = http.NewServeMux()
+		}
+		return muxes[addr]
+	}
+
+	if opts.MetricsAddr != "" {
+		muxFor(opts.MetricsAddr).Handle("/metrics", c.metricsHandler(refresher))
+		c.output.Printf("Serving metrics on %s/metrics\n", opts.MetricsAddr)
+	}
+
+	if opts.APIAddr != "" {
+		c.registerAPI(muxFor(opts.APIAddr), refresher)
+		c.output.Printf("Serving JSON API on %s (/repos, /summary, /events, /health)\n", opts.APIAddr)
+	}
+
+	if opts.WebhooksAddr != "" {
+		secret := os.Getenv(opts.WebhookSecretEnv)
+		if secret == "" {
+			return fmt.Errorf("webhook secret not set: export %s or use --webhook-secret-env", opts.WebhookSecretEnv)
+		}
+		muxFor(opts.WebhooksAddr).Handle("/webhook", &webhookReceiver{cli: c, secret: secret})
+		c.output.Printf("Receiving GitHub webhooks on %s/webhook\n", opts.WebhooksAddr)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var servers []*http.Server
+	serverErr := make(chan error, len(muxes))
+	for addr, mux := range muxes {
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		servers = append(servers, server)
+
+		go func() {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErr <- err
+			}
+		}()
+	}
+
+	if opts.MetricsAddr != "" || opts.TextfilePath != "" || opts.APIAddr != "" {
+		go refresher.Run(ctx)
+	}
+
+	var err error
+	select {
+	case err = <-serverErr:
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, server := range servers {
+		if shutdownErr := server.Shutdown(shutdownCtx); err == nil {
+			err = shutdownErr
+		}
+	}
+	return err
+}
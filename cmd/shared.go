@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"io"
+	"os"
+	"path/filepath"
+
 	"github.com/jackchuka/gh-oss-watch/services"
 )
 
@@ -22,15 +26,43 @@ type RepoStatsProcessor interface {
 	ProcessRepo(repoConfig services.RepoConfig, stats *services.RepoStats, index int) error
 }
 
+// eventPrefetcher is a processor whose event states can be fetched together with the stats,
+// so that event sources of different repositories are fetched concurrently
+type eventPrefetcher interface {
+	RepoStatsProcessor
+	// eventsRequest returns what to fetch for a repository
+	eventsRequest(repoConfig services.RepoConfig) services.RepoEventsRequest
+	// setPrefetched stores the fetched event states for ProcessRepo to use
+	setPrefetched(repo string, events prefetchedEvents)
+}
+
+// prefetchedEvents are event states fetched in the batch alongside a repository's stats
+type prefetchedEvents struct {
+	States map[string]services.EventState
+	Errors map[string]error
+}
+
+// repoFailure records a repository whose stats could not be fetched
+type repoFailure struct {
+	Repo string
+	Err  error
+}
+
 func (c *CLI) processReposWithBatch(
 	config *services.Config,
 	processor RepoStatsProcessor,
-) error {
+) ([]repoFailure, error) {
 	batchService, canBatch := c.githubService.(services.BatchGitHubService)
 	if !canBatch || len(config.Repos) <= 1 {
 		return c.processReposSequentially(config, processor)
 	}
 
+	if eventBatch, ok := batchService.(services.BatchEventGitHubService); ok {
+		if prefetcher, ok := processor.(eventPrefetcher); ok {
+			return c.processReposWithEventBatch(config, eventBatch, prefetcher)
+		}
+	}
+
 	repos := make([]string, len(config.Repos))
 	for i, repoConfig := range config.Repos {
 		repos[i] = repoConfig.Repo
@@ -38,9 +70,11 @@ func (c *CLI) processReposWithBatch(
 
 	allStats, allErrors := batchService.GetRepoStatsBatch(repos)
 
+	var failures []repoFailure
 	for i, repoConfig := range config.Repos {
 		if allErrors[i] != nil {
 			c.output.Printf("Error fetching stats for %s: %v\n", repoConfig.Repo, allErrors[i])
+			failures = append(failures, repoFailure{Repo: repoConfig.Repo, Err: allErrors[i]})
 			continue
 		}
 
@@ -50,34 +84,97 @@ func (c *CLI) processReposWithBatch(
 		}
 
 		if err := processor.ProcessRepo(repoConfig, stats, i); err != nil {
-			return err
+			return failures, err
 		}
 	}
 
-	return nil
+	return failures, nil
+}
+
+// processReposWithEventBatch fetches stats and event states in the service's worker pool,
+// then processes the repositories in config order
+func (c *CLI) processReposWithEventBatch(
+	config *services.Config,
+	batchService services.BatchEventGitHubService,
+	processor eventPrefetcher,
+) ([]repoFailure, error) {
+	requests := make([]services.RepoEventsRequest, len(config.Repos))
+	for i, repoConfig := range config.Repos {
+		requests[i] = processor.eventsRequest(repoConfig)
+	}
+
+	results := batchService.GetRepoEventsBatch(requests)
+
+	var failures []repoFailure
+	for i, repoConfig := range config.Repos {
+		result := results[i]
+		if result.Error != nil {
+			c.output.Printf("Error fetching stats for %s: %v\n", repoConfig.Repo, result.Error)
+			failures = append(failures, repoFailure{Repo: repoConfig.Repo, Err: result.Error})
+			continue
+		}
+		if result.Stats == nil {
+			continue
+		}
+
+		processor.setPrefetched(repoConfig.Repo, prefetchedEvents{States: result.States, Errors: result.EventErrors})
+		if err := processor.ProcessRepo(repoConfig, result.Stats, i); err != nil {
+			return failures, err
+		}
+	}
+
+	return failures, nil
 }
 
 func (c *CLI) processReposSequentially(
 	config *services.Config,
 	processor RepoStatsProcessor,
-) error {
+) ([]repoFailure, error) {
+	var failures []repoFailure
 	for i, repoConfig := range config.Repos {
 		owner, repo, err := services.ParseRepoString(repoConfig.Repo)
 		if err != nil {
 			c.output.Printf("Error parsing repo %s: %v\n", repoConfig.Repo, err)
+			failures = append(failures, repoFailure{Repo: repoConfig.Repo, Err: err})
 			continue
 		}
 
 		stats, err := c.githubService.GetRepoStats(owner, repo)
 		if err != nil {
 			c.output.Printf("Error fetching stats for %s: %v\n", repoConfig.Repo, err)
+			failures = append(failures, repoFailure{Repo: repoConfig.Repo, Err: err})
 			continue
 		}
 
 		if err := processor.ProcessRepo(repoConfig, stats, i); err != nil {
-			return err
+			return failures, err
 		}
 	}
 
-	return nil
+	return failures, nil
+}
+
+// writeFileAtomic writes to a temporary file next to path and renames it into place,
+// so readers never observe a partially written file
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err := write(tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
 }
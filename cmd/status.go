@@ -1,6 +1,12 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/jackchuka/gh-oss-watch/services"
@@ -9,7 +15,21 @@ import (
 type statusProcessor struct {
 	output     services.Output
 	cache      *services.CacheData
+	history    *services.HistoryData
+	listIssues func(owner, repo string, since time.Time) ([]services.IssueAPIData, error)
+
+	// fetchEvents reads event source states; when nil, only sources derived from the stats are read
+	fetchEvents func(stats *services.RepoStats, events []string, previous services.RepoState) (map[string]services.EventState, map[string]error)
+	// prefetched holds event states already fetched alongside the stats, keyed by repository
+	prefetched map[string]prefetchedEvents
+	run        *services.RunResult
+	lastCheck  time.Time
+	checkedAt  time.Time
 	hasChanges *bool
+
+	// delivery marks an update from a webhook delivery: events outside the repo config are
+	// kept as cached, and the fetch times and stats history are left to the scheduled poll
+	delivery bool
 }
 
 func (s *statusProcessor) ProcessRepo(repoConfig services.RepoConfig, stats *services.RepoStats, index int) error {
@@ -18,82 +38,349 @@ func (s *statusProcessor) ProcessRepo(repoConfig services.RepoConfig, stats *ser
 		previousState = services.RepoState{}
 	}
 
-	summary := services.CalculateEventSummary(repoConfig.Repo, stats, previousState)
+	states, errs := s.fetchEventStates(repoConfig.Repo, stats, repoConfig.Events, previousState)
+	summary, changes := services.CalculateEventSummary(repoConfig.Repo, states, previousState)
+
+	// Keep the stored state of sources that could not be read so their changes are picked up later
+	events := make(map[string]services.EventState, len(repoConfig.Events))
+	if s.delivery {
+		maps.Copy(events, previousState.Events)
+	}
+	for _, event := range repoConfig.Events {
+		if state, ok := states[event]; ok {
+			events[event] = state
+		} else if state, ok := previousState.Events[event]; ok {
+			events[event] = state
+		}
+	}
+
+	result := services.RepoRunResult{
+		Repo:    repoConfig.Repo,
+		Events:  repoConfig.Events,
+		Stats:   stats,
+		State:   states,
+		Summary: summary,
+		Changes: changes,
+	}
+	for event, err := range errs {
+		if !errors.Is(err, services.ErrNoAPIClient) {
+			s.output.Printf("Warning: Error fetching %s for %s: %v\n", event, repoConfig.Repo, err)
+		}
+		if result.EventErrors == nil {
+			result.EventErrors = make(map[string]string)
+		}
+		result.EventErrors[event] = err.Error()
+	}
 
 	if summary.HasChanges {
 		*s.hasChanges = true
 		s.output.Printf("\n📈 %s:\n", repoConfig.Repo)
 
-		for _, event := range repoConfig.Events {
-			switch event {
-			case "stars":
-				if summary.NewStars > 0 {
-					s.output.Printf("  ⭐ +%d stars (%d total)\n", summary.NewStars, stats.Stars)
-				}
-			case "issues":
-				if summary.NewIssues > 0 {
-					s.output.Printf("  🐛 +%d issues (%d open)\n", summary.NewIssues, stats.Issues)
-				}
-			case "pull_requests":
-				if summary.NewPRs > 0 {
-					s.output.Printf("  🔀 +%d pull requests (%d open)\n", summary.NewPRs, stats.PullRequests)
-				}
-			case "forks":
-				if summary.NewForks > 0 {
-					s.output.Printf("  🍴 +%d forks (%d total)\n", summary.NewForks, stats.Forks)
-				}
+		for _, event := range result.Events {
+			change := result.Change(event)
+			if change.Count <= 0 {
+				continue
+			}
+			s.output.Printf("  %s\n", services.DescribeChange(event, change, result.CurrentState(event)))
+			for _, detail := range services.ChangeDetails(event, change) {
+				s.output.Printf("    ↳ %s\n", detail)
 			}
 		}
+
+		// Skip repos seen for the first time so their initial counts are not recorded as activity
+		if exists {
+			since := previousState.LastFetched
+			if since.IsZero() {
+				since = s.lastCheck
+			}
+			result.Activity = s.recordEvents(repoConfig, stats, changes, since)
+		}
 	}
+	s.run.Repos = append(s.run.Repos, result)
 
-	s.cache.Repos[repoConfig.Repo] = services.RepoState{
-		LastStarCount:  stats.Stars,
-		LastIssueCount: stats.Issues,
-		LastPRCount:    stats.PullRequests,
-		LastForkCount:  stats.Forks,
-		LastUpdated:    stats.UpdatedAt,
+	lastActivity := previousState.LastActivity
+	if summary.HasChanges && exists {
+		lastActivity = s.checkedAt
+	}
+
+	repoState := services.RepoState{
+		Events:       events,
+		LastUpdated:  stats.UpdatedAt,
+		LastFetched:  s.checkedAt,
+		LastActivity: lastActivity,
+	}
+	if s.delivery {
+		repoState.LastUpdated = previousState.LastUpdated
+		repoState.LastFetched = previousState.LastFetched
+	} else {
+		s.history.Record(repoConfig.Repo, stats, s.checkedAt)
+	}
+	s.cache.Repos[repoConfig.Repo] = repoState
+	for event, state := range states {
+		if source, ok := services.LookupEventSource(event); ok {
+			if merger, ok := source.(services.HistoryMerger); ok {
+				merger.MergeHistory(s.history, repoConfig.Repo, state, s.checkedAt)
+			}
+		}
 	}
 
 	return nil
 }
 
-func (c *CLI) handleStatus() error {
+func (s *statusProcessor) eventsRequest(repoConfig services.RepoConfig) services.RepoEventsRequest {
+	return services.RepoEventsRequest{Repo: repoConfig.Repo, Events: repoConfig.Events, Previous: s.cache.Repos[repoConfig.Repo]}
+}
+
+func (s *statusProcessor) setPrefetched(repo string, events prefetchedEvents) {
+	if s.prefetched == nil {
+		s.prefetched = make(map[string]prefetchedEvents)
+	}
+	s.prefetched[repo] = events
+}
+
+func (s *statusProcessor) fetchEventStates(repo string, stats *services.RepoStats, events []string, previous services.RepoState) (map[string]services.EventState, map[string]error) {
+	if prefetched, ok := s.prefetched[repo]; ok {
+		delete(s.prefetched, repo)
+		return prefetched.States, prefetched.Errors
+	}
+	if s.fetchEvents != nil {
+		return s.fetchEvents(stats, events, previous)
+	}
+	return services.FetchEventStates(context.Background(), nil, stats, events, previous)
+}
+
+// recordEvents stores the repo's changes in the event history: the items an event source
+// reported, one event per new issue or pull request when they can be listed, and one
+// event per batch otherwise. It returns the events that were newly recorded.
+func (s *statusProcessor) recordEvents(repoConfig services.RepoConfig, stats *services.RepoStats, changes map[string]services.EventChange, since time.Time) []services.HistoryEvent {
+	var recorded []services.HistoryEvent
+	record := func(event services.HistoryEvent) {
+		if s.history.AddEvent(event) {
+			recorded = append(recorded, event)
+		}
+	}
+
+	watchesIssues := slices.Contains(repoConfig.Events, "issues")
+	watchesPRs := slices.Contains(repoConfig.Events, "pull_requests")
+
+	itemsRecorded := false
+	if s.listIssues != nil && ((watchesIssues && changes["issues"].Count > 0) || (watchesPRs && changes["pull_requests"].Count > 0)) {
+		issues, err := s.listIssues(stats.Owner, stats.Name, since)
+		if err != nil {
+			s.output.Printf("Warning: Error listing new issues for %s: %v\n", repoConfig.Repo, err)
+		} else {
+			itemsRecorded = true
+			for _, issue := range issues {
+				if (issue.PullRequest != nil && watchesPRs) || (issue.PullRequest == nil && watchesIssues) {
+					record(services.NewIssueEvent(repoConfig.Repo, issue))
+				}
+			}
+		}
+	}
+
+	for _, event := range repoConfig.Events {
+		change, ok := changes[event]
+		if !ok {
+			continue
+		}
+		if len(change.Items) > 0 {
+			for _, item := range change.Items {
+				record(item)
+			}
+			continue
+		}
+		if itemsRecorded && (event == "issues" || event == "pull_requests") {
+			continue
+		}
+		record(services.NewCountEvent(repoConfig.Repo, event, change.Count, s.checkedAt))
+	}
+
+	return recorded
+}
+
+// statusCycle is the outcome of one pass over the configured repositories
+type statusCycle struct {
+	Repos      int
+	Failures   []repoFailure
+	HasChanges bool
+	Run        *services.RunResult
+
+	// NextDue is when the next skipped repository becomes due, zero if none were skipped
+	NextDue time.Time
+}
+
+func (c *CLI) handleStatus(opts statusOptions) error {
+	cycle, err := c.runStatusCycle(opts)
+	if err != nil || cycle == nil {
+		return err
+	}
+
+	if c.actions != nil {
+		if err := c.actions.reportStatus(cycle); err != nil {
+			c.output.Printf("Warning: Error writing GitHub Actions results: %v\n", err)
+		}
+	}
+
+	return c.setOutcome(cycle.Repos, cycle.Failures, cycle.HasChanges)
+}
+
+// runStatusCycle detects new activity, then (unless checking) persists cache and history
+// and notifies. It returns nil when no repositories are configured.
+func (c *CLI) runStatusCycle(opts statusOptions) (*statusCycle, error) {
 	config, err := c.validateConfig()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(config.Repos) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	cache, err := c.cacheService.Load()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	history, err := c.historyService.Load()
+	if err != nil {
+		return nil, err
 	}
 
 	hasChanges := false
+	checkedAt := time.Now()
+	run := &services.RunResult{CheckedAt: checkedAt}
+
+	schedules, err := services.ScheduleRepos(config, cache, checkedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	due := *config
+	due.Repos = nil
+	var skipped []services.RepoSchedule
+	for _, schedule := range schedules {
+		if schedule.Due || opts.All {
+			due.Repos = append(due.Repos, schedule.Repo)
+		} else {
+			skipped = append(skipped, schedule)
+		}
+	}
 
 	processor := &statusProcessor{
 		output:     c.output,
 		cache:      cache,
+		history:    history,
+		run:        run,
+		lastCheck:  cache.LastCheck,
+		checkedAt:  checkedAt,
 		hasChanges: &hasChanges,
 	}
+	if activity, ok := c.githubService.(services.ActivityGitHubService); ok && !opts.Check {
+		processor.listIssues = activity.GetNewIssues
+	}
+	if sources, ok := c.githubService.(services.EventGitHubService); ok {
+		processor.fetchEvents = sources.FetchEventStates
+	}
 
-	err = c.processReposWithBatch(config, processor)
-	if err != nil {
-		return err
+	var failures []repoFailure
+	if len(due.Repos) > 0 {
+		failures, err = c.processReposWithBatch(&due, processor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, failure := range failures {
+		repoConfig := config.GetRepo(failure.Repo)
+		run.Repos = append(run.Repos, services.RepoRunResult{
+			Repo:      failure.Repo,
+			Events:    repoConfig.Events,
+			Error:     failure.Err.Error(),
+			ErrorType: services.ErrorTypeOf(failure.Err),
+		})
 	}
 
 	if !hasChanges {
 		c.output.Println("No new activity since last check.")
 	}
 
-	cache.LastCheck = time.Now()
+	c.reportStale(skipped, cache, run, checkedAt)
+
+	cycle := &statusCycle{
+		Repos:      len(due.Repos),
+		Failures:   failures,
+		HasChanges: hasChanges,
+		Run:        run,
+	}
+	if !opts.All {
+		cycle.NextDue = services.NextDue(schedules)
+	}
+
+	if opts.Check {
+		return cycle, nil
+	}
+
+	if cache.Throttle == nil {
+		cache.Throttle = make(map[string]time.Time)
+	}
+	c.notify(config, run, cache.Throttle)
+
+	cache.LastCheck = checkedAt
 	err = c.cacheService.Save(cache)
 	if err != nil {
 		c.output.Printf("Warning: Error saving cache: %v\n", err)
 	}
 
-	return nil
+	err = c.historyService.Save(history)
+	if err != nil {
+		c.output.Printf("Warning: Error saving history: %v\n", err)
+	}
+
+	return cycle, nil
+}
+
+// reportStale lists repositories skipped by the scheduler with their cached counts
+// and adds them to the run marked as stale
+func (c *CLI) reportStale(skipped []services.RepoSchedule, cache *services.CacheData, run *services.RunResult, now time.Time) {
+	if len(skipped) == 0 {
+		return
+	}
+
+	c.output.Printf("\n⏸  Not due yet (cached values):\n")
+	for _, schedule := range skipped {
+		state := cache.Repos[schedule.Repo.Repo]
+		c.output.Printf("  %s: %s (stale, fetched %s ago, next in %s)\n",
+			schedule.Repo.Repo, cachedCounts(schedule.Repo.Events, state),
+			now.Sub(schedule.LastFetched).Round(time.Minute), schedule.NextDue.Sub(now).Round(time.Minute))
+
+		stats := &services.RepoStats{
+			Stars:        state.Count("stars"),
+			Issues:       state.Count("issues"),
+			PullRequests: state.Count("pull_requests"),
+			Forks:        state.Count("forks"),
+			UpdatedAt:    state.LastUpdated,
+		}
+		if owner, name, err := services.ParseRepoString(schedule.Repo.Repo); err == nil {
+			stats.Owner, stats.Name = owner, name
+		}
+
+		run.Repos = append(run.Repos, services.RepoRunResult{
+			Repo:    schedule.Repo.Repo,
+			Events:  schedule.Repo.Events,
+			Stats:   stats,
+			State:   maps.Clone(state.Events),
+			Summary: services.EventSummary{Repo: schedule.Repo.Repo},
+			Stale:   true,
+		})
+	}
+}
+
+// cachedCounts lists the cached count of each watched event, e.g. "⭐ 120  🐛 4"
+func cachedCounts(events []string, state services.RepoState) string {
+	var parts []string
+	for _, event := range events {
+		if source, ok := services.LookupEventSource(event); ok {
+			parts = append(parts, fmt.Sprintf("%s %d", source.Emoji(), state.Count(event)))
+		}
+	}
+	return strings.Join(parts, "  ")
 }
@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleStatus_CheckDoesNotAdvanceCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockConfig := mock_services.NewMockConfigService(ctrl)
+	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
+	mockGitHub := mock_services.NewMockGitHubService(ctrl)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
+
+	mockConfig.EXPECT().Load().Return(&services.Config{Repos: []services.RepoConfig{
+		{Repo: "owner/repo", Events: []string{"stars"}},
+	}}, nil)
+	mockCache.EXPECT().Load().Return(&services.CacheData{Repos: map[string]services.RepoState{
+		"owner/repo": {Events: map[string]services.EventState{"stars": {Count: 10}}},
+	}}, nil)
+	mockHistory.EXPECT().Load().Return(&services.HistoryData{}, nil)
+	mockGitHub.EXPECT().GetRepoStats("owner", "repo").Return(&services.RepoStats{Stars: 12}, nil)
+	mockOutput.EXPECT().Printf(gomock.Any(), gomock.Any()).AnyTimes()
+	// No Save expectations: --check must leave cache and history untouched
+
+	if err := cli.handleStatus(statusOptions{Check: true}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cli.exitCode != ExitChanges {
+		t.Errorf("Expected exit code %d, got %d", ExitChanges, cli.exitCode)
+	}
+}
+
+func TestHandleStatus_TotalFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockConfig := mock_services.NewMockConfigService(ctrl)
+	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
+	mockGitHub := mock_services.NewMockGitHubService(ctrl)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
+
+	mockConfig.EXPECT().Load().Return(&services.Config{Repos: []services.RepoConfig{
+		{Repo: "owner/repo", Events: []string{"stars"}},
+	}}, nil)
+	mockCache.EXPECT().Load().Return(&services.CacheData{Repos: map[string]services.RepoState{}}, nil)
+	mockHistory.EXPECT().Load().Return(&services.HistoryData{}, nil)
+	mockGitHub.EXPECT().GetRepoStats("owner", "repo").Return(nil, fmt.Errorf("boom"))
+	mockOutput.EXPECT().Printf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockOutput.EXPECT().Println(gomock.Any()).AnyTimes()
+
+	err := cli.handleStatus(statusOptions{Check: true})
+	if err == nil {
+		t.Error("Expected error when every repository fails, got nil")
+	}
+}
+
+func TestHandleStatus_FetchesEventsInTheBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockConfig := mock_services.NewMockConfigService(ctrl)
+	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
+	mockGitHub := mock_services.NewMockBatchEventGitHubService(ctrl)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
+
+	previous := services.RepoState{Events: map[string]services.EventState{"releases": {Count: 2, Cursor: "7"}}}
+	mockConfig.EXPECT().Load().Return(&services.Config{Repos: []services.RepoConfig{
+		{Repo: "owner/a", Events: []string{"releases"}},
+		{Repo: "owner/b", Events: []string{"stars"}},
+	}}, nil)
+	mockCache.EXPECT().Load().Return(&services.CacheData{Repos: map[string]services.RepoState{"owner/a": previous}}, nil)
+	mockHistory.EXPECT().Load().Return(&services.HistoryData{}, nil)
+	mockGitHub.EXPECT().GetRepoEventsBatch([]services.RepoEventsRequest{
+		{Repo: "owner/a", Events: []string{"releases"}, Previous: previous},
+		{Repo: "owner/b", Events: []string{"stars"}},
+	}).Return([]services.RepoEventsResult{
+		{
+			Stats:  &services.RepoStats{Owner: "owner", Name: "a"},
+			States: map[string]services.EventState{"releases": {Count: 3, Cursor: "8", New: []services.HistoryEvent{{Type: services.HistoryEventRelease, Title: "v1.0.0"}}}},
+		},
+		{Error: fmt.Errorf("boom")},
+	})
+
+	var lines []string
+	mockOutput.EXPECT().Printf(gomock.Any(), gomock.Any()).DoAndReturn(func(format string, args ...any) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}).AnyTimes()
+
+	if err := cli.handleStatus(statusOptions{Check: true}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	output := strings.Join(lines, "")
+	for _, want := range []string{"🚀 +1 release: v1.0.0", "Error fetching stats for owner/b: boom"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, output)
+		}
+	}
+	if cli.exitCode != ExitPartialFailure {
+		t.Errorf("Expected exit code %d, got %d", ExitPartialFailure, cli.exitCode)
+	}
+}
+
+func TestSetOutcome(t *testing.T) {
+	cli := &CLI{}
+	failures := []repoFailure{{Repo: "owner/a", Err: fmt.Errorf("boom")}}
+
+	tests := []struct {
+		name       string
+		failures   []repoFailure
+		hasChanges bool
+		want       int
+	}{
+		{"no changes", nil, false, ExitOK},
+		{"changes", nil, true, ExitChanges},
+		{"partial failure wins over changes", failures, true, ExitPartialFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := cli.setOutcome(2, tt.failures, tt.hasChanges); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if cli.exitCode != tt.want {
+				t.Errorf("Expected exit code %d, got %d", tt.want, cli.exitCode)
+			}
+		})
+	}
+}
+
+func TestHandleStatus_SkipsReposNotDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockConfig := mock_services.NewMockConfigService(ctrl)
+	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
+	mockGitHub := mock_services.NewMockGitHubService(ctrl)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
+
+	now := time.Now()
+	mockConfig.EXPECT().Load().Return(&services.Config{Repos: []services.RepoConfig{
+		{Repo: "owner/flagship", Events: []string{"stars"}, Interval: "5m"},
+		{Repo: "owner/library", Events: []string{"stars"}, Interval: "1d"},
+	}}, nil)
+	mockCache.EXPECT().Load().Return(&services.CacheData{Repos: map[string]services.RepoState{
+		"owner/flagship": {Events: map[string]services.EventState{"stars": {Count: 100}}, LastFetched: now.Add(-10 * time.Minute)},
+		"owner/library":  {Events: map[string]services.EventState{"stars": {Count: 7}}, LastFetched: now.Add(-2 * time.Hour)},
+	}}, nil)
+	mockHistory.EXPECT().Load().Return(&services.HistoryData{}, nil)
+	mockGitHub.EXPECT().GetRepoStats("owner", "flagship").Return(&services.RepoStats{Stars: 100}, nil)
+	// No GetRepoStats for owner/library: it was fetched 2h ago and is checked daily
+
+	var stale []string
+	mockOutput.EXPECT().Printf(gomock.Any(), gomock.Any()).AnyTimes().Do(func(format string, args ...any) {
+		if strings.Contains(format, "stale") {
+			stale = append(stale, fmt.Sprint(args[0]))
+		}
+	})
+	mockOutput.EXPECT().Println(gomock.Any()).AnyTimes()
+
+	cycle, err := cli.runStatusCycle(statusOptions{Check: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(stale) != 1 || stale[0] != "owner/library" {
+		t.Errorf("Expected owner/library to be shown as stale, got %v", stale)
+	}
+	if cycle.Repos != 1 {
+		t.Errorf("Expected one repository to be fetched, got %d", cycle.Repos)
+	}
+	if want := now.Add(22 * time.Hour); cycle.NextDue.Sub(want).Abs() > time.Minute {
+		t.Errorf("Expected owner/library to be due around %v, got %v", want, cycle.NextDue)
+	}
+}
+
+// statusHarness runs a statusProcessor for owner/repo against a mock API client and collects what it prints
+type statusHarness struct {
+	client     *mock_services.MockGitHubAPIClient
+	cache      *services.CacheData
+	history    *services.HistoryData
+	processor  *statusProcessor
+	hasChanges bool
+	lines      []string
+}
+
+func newStatusHarness(t *testing.T, events map[string]services.EventState) *statusHarness {
+	ctrl := gomock.NewController(t)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+
+	h := &statusHarness{
+		client:  mock_services.NewMockGitHubAPIClient(ctrl),
+		cache:   &services.CacheData{Repos: map[string]services.RepoState{"owner/repo": {Events: events}}},
+		history: &services.HistoryData{},
+	}
+	mockOutput.EXPECT().Printf(gomock.Any(), gomock.Any()).DoAndReturn(func(format string, args ...any) {
+		h.lines = append(h.lines, fmt.Sprintf(format, args...))
+	}).AnyTimes()
+
+	now := time.Now()
+	h.processor = &statusProcessor{
+		output:     mockOutput,
+		cache:      h.cache,
+		history:    h.history,
+		run:        &services.RunResult{CheckedAt: now},
+		checkedAt:  now,
+		hasChanges: &h.hasChanges,
+		fetchEvents: func(stats *services.RepoStats, events []string, previous services.RepoState) (map[string]services.EventState, map[string]error) {
+			return services.FetchEventStates(context.Background(), h.client, stats, events, previous)
+		},
+	}
+	return h
+}
+
+// process runs the processor for the given events and returns what it printed
+func (h *statusHarness) process(t *testing.T, stats *services.RepoStats, events ...string) string {
+	t.Helper()
+
+	if stats == nil {
+		stats = &services.RepoStats{Owner: "owner", Name: "repo"}
+	}
+	h.lines = nil
+	if err := h.processor.ProcessRepo(services.RepoConfig{Repo: "owner/repo", Events: events}, stats, 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return strings.Join(h.lines, "")
+}
+
+func TestStatusProcessor_RecordsReportedItems(t *testing.T) {
+	h := newStatusHarness(t, map[string]services.EventState{
+		"releases": {Count: 1, Cursor: "10", Values: map[string]string{"tags": "v1.1.0"}},
+	})
+
+	now := time.Now()
+	h.client.EXPECT().GetReleases(gomock.Any(), "owner", "repo").Return([]services.ReleaseAPIData{
+		{ID: 11, TagName: "v1.2.0", Author: services.UserAPIData{Login: "alice"}, PublishedAt: now},
+		{ID: 10, TagName: "v1.1.0", PublishedAt: now.Add(-30 * 24 * time.Hour)},
+	}, nil)
+	h.client.EXPECT().GetTags(gomock.Any(), "owner", "repo").Return([]services.TagAPIData{{Name: "v1.2.0"}, {Name: "v1.1.0"}}, nil)
+
+	want := "🚀 +1 release: v1.2.0 by alice"
+	if output := h.process(t, nil, "releases"); !strings.Contains(output, want) {
+		t.Errorf("Expected %q in output, got:\n%s", want, output)
+	}
+	if !h.hasChanges || len(h.history.Events) != 1 || h.history.Events[0].ID != "owner/repo/release/11" {
+		t.Errorf("Expected the release to be recorded in history, got %+v", h.history.Events)
+	}
+	if state := h.cache.Repos["owner/repo"].Events["releases"]; state.Cursor != "11" {
+		t.Errorf("Expected the releases state to be cached, got %+v", state)
+	}
+}
+
+func TestStatusProcessor_SecurityDegradesWithoutAccess(t *testing.T) {
+	h := newStatusHarness(t, map[string]services.EventState{
+		"security": {Count: 1, Cursor: time.Now().Add(-time.Hour).Format(time.RFC3339)},
+	})
+
+	alert := services.DependabotAlertAPIData{Number: 1, CreatedAt: time.Now().Add(-24 * time.Hour)}
+	alert.SecurityAdvisory.Severity = "high"
+	h.client.EXPECT().GetDependabotAlerts(gomock.Any(), "owner", "repo").Return([]services.DependabotAlertAPIData{alert}, nil)
+	h.client.EXPECT().GetCodeScanningAlerts(gomock.Any(), "owner", "repo").
+		Return(nil, services.NewAPIError("access forbidden", 403, "owner/repo", nil))
+	h.client.EXPECT().GetSecretScanningAlerts(gomock.Any(), "owner", "repo").
+		Return(nil, services.NewAPIError("resource not found", 404, "owner/repo", nil))
+
+	h.process(t, nil, "security")
+	if errs := h.processor.run.Repos[0].EventErrors; len(errs) != 0 {
+		t.Errorf("Expected permission errors to degrade silently, got %v", errs)
+	}
+
+	_, line, _ := dashboardLine("security", h.cache.Repos["owner/repo"].Events)
+	if want := "Dependabot 1 high · code scanning n/a · secret scanning n/a"; line != want {
+		t.Errorf("Expected dashboard line %q, got %q", want, line)
+	}
+}
+
+func TestStatusProcessor_ArchivesTraffic(t *testing.T) {
+	h := newStatusHarness(t, nil)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	h.client.EXPECT().GetTraffic(gomock.Any(), "owner", "repo").Return(&services.TrafficAPIData{
+		Views: services.TrafficSeriesAPIData{Count: 50, Uniques: 20, Days: []services.TrafficCountAPIData{{Timestamp: today, Count: 50, Uniques: 20}}},
+	}, nil)
+
+	h.process(t, nil, "traffic")
+	if days := h.history.Traffic["owner/repo"].Days; len(days) != 1 || days[0].Views != 50 {
+		t.Errorf("Expected today's traffic to be archived, got %+v", days)
+	}
+	if h.hasChanges {
+		t.Error("Expected traffic to be archived without reporting changes")
+	}
+}
+
+func TestStatusProcessor_PrintsChangeDetails(t *testing.T) {
+	now := time.Now()
+	h := newStatusHarness(t, map[string]services.EventState{
+		"mentions": {Count: 5, Cursor: now.Add(-time.Hour).Format(time.RFC3339)},
+	})
+
+	h.client.EXPECT().GetAuthenticatedUser(gomock.Any()).Return(&services.UserAPIData{Login: "me"}, nil)
+	h.client.EXPECT().GetIssueCommentsSince(gomock.Any(), "owner", "repo", gomock.Any()).Return([]services.IssueCommentAPIData{{
+		ID: 1, Body: "@me could you review?", IssueURL: "api/12", CreatedAt: now.Add(-time.Minute),
+		HTMLURL: "https://github.com/owner/repo/issues/12#issuecomment-1", User: services.UserAPIData{Login: "alice"},
+	}}, nil)
+	h.client.EXPECT().GetIssuesUpdatedSince(gomock.Any(), "owner", "repo", gomock.Any()).Return([]services.IssueAPIData{
+		{Number: 12, Title: "Crash on start", URL: "api/12", User: services.UserAPIData{Login: "alice"}},
+	}, nil)
+
+	output := h.process(t, nil, "mentions")
+	for _, want := range []string{
+		"📣 +1 mention: @alice mentioned you on #12\n",
+		"    ↳ #12 Crash on start: https://github.com/owner/repo/issues/12#issuecomment-1\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestStatusProcessor_KeepsStateOfUnavailableSources(t *testing.T) {
+	h := newStatusHarness(t, map[string]services.EventState{
+		"metadata": {Values: map[string]string{"archived": "false", "default_branch": "master"}},
+	})
+
+	stats := &services.RepoStats{Owner: "owner", Name: "repo", Metadata: &services.RepoMetadata{Archived: true, DefaultBranch: "main"}}
+	want := "📝 +2 metadata changes: archived: false → true, default_branch: master → main\n"
+	if output := h.process(t, stats, "metadata"); !strings.Contains(output, want) {
+		t.Errorf("Expected %q in output, got:\n%s", want, output)
+	}
+	if len(h.history.Events) != 2 || h.history.Events[0].Type != services.HistoryEventMetadataChange {
+		t.Errorf("Expected two metadata change events, got %+v", h.history.Events)
+	}
+
+	// Stats without metadata, e.g. from webhooks, keep the cached settings
+	h.process(t, nil, "metadata")
+	if h.cache.Repos["owner/repo"].Events["metadata"].Values["default_branch"] != "main" {
+		t.Error("Expected cached settings to be kept when metadata is unavailable")
+	}
+}
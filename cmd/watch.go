@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+// Health states reported by watch
+const (
+	watchStarting = "starting"
+	watchOK       = "ok"
+	watchDegraded = "degraded"
+	watchFailing  = "failing"
+)
+
+// staleCycles is how many intervals may pass without a successful cycle before watch reports itself unhealthy
+const staleCycles = 3
+
+type watchOptions struct {
+	Interval   time.Duration
+	Jitter     time.Duration
+	HealthAddr string
+	HealthFile string
+}
+
+func parseWatchArgs(args []string) (watchOptions, error) {
+	opts := watchOptions{
+		Interval: 15 * time.Minute,
+		Jitter:   -1,
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		var value string
+		name, inline, hasInline := strings.Cut(arg, "=")
+		if hasInline {
+			value = inline
+		} else if i+1 < len(args) {
+			value = args[i+1]
+			i++ // Skip next arg
+		} else {
+			return opts, fmt.Errorf("%s requires a value", name)
+		}
+
+		switch name {
+		case "--interval":
+			interval, err := services.ParseDuration(value)
+			if err != nil {
+				return opts, err
+			}
+			if interval <= 0 {
+				return opts, fmt.Errorf("interval must be positive")
+			}
+			opts.Interval = interval
+		case "--jitter":
+			jitter, err := services.ParseDuration(value)
+			if err != nil {
+				return opts, err
+			}
+			if jitter < 0 {
+				return opts, fmt.Errorf("jitter must not be negative")
+			}
+			opts.Jitter = jitter
+		case "--health-addr":
+			opts.HealthAddr = value
+		case "--health-file":
+			opts.HealthFile = value
+		default:
+			return opts, fmt.Errorf("unknown watch argument: %s", arg)
+		}
+	}
+
+	// Default to spreading cycles over a tenth of the interval
+	if opts.Jitter < 0 {
+		opts.Jitter = opts.Interval / 10
+	}
+
+	return opts, nil
+}
+
+func (c *CLI) handleWatchCommand(args []string, flags GlobalFlags) error {
+	opts, err := parseWatchArgs(args)
+	if err != nil {
+		c.output.Println("Usage: gh oss-watch watch [--interval 15m] [--jitter 1m] [--health-addr <addr>] [--health-file <path>]")
+		return err
+	}
+
+	c.githubService.SetMaxConcurrent(flags.MaxConcurrent)
+	c.githubService.SetTimeout(time.Duration(flags.Timeout) * time.Second)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return c.handleWatch(ctx, opts)
+}
+
+// watchHealth is served on /healthz and written to the health file after every cycle
+type watchHealth struct {
+	Status              string           `json:"status"`
+	StartedAt           time.Time        `json:"started_at"`
+	Interval            string           `json:"interval"`
+	Cycles              int              `json:"cycles"`
+	LastCycleAt         time.Time        `json:"last_cycle_at,omitzero"`
+	LastSuccessAt       time.Time        `json:"last_success_at,omitzero"`
+	LastDuration        string           `json:"last_duration,omitempty"`
+	ConsecutiveFailures int              `json:"consecutive_failures"`
+	LastError           string           `json:"last_error,omitempty"`
+	FailedRepos         []string         `json:"failed_repos,omitempty"`
+	NextCycleAt         time.Time        `json:"next_cycle_at,omitzero"`
+	RateLimit           *rateLimitStatus `json:"rate_limit,omitempty"`
+}
+
+type rateLimitStatus struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// watcher runs status cycles on a schedule and tracks their health
+type watcher struct {
+	cli  *CLI
+	opts watchOptions
+
+	mu     sync.RWMutex
+	health watchHealth
+
+	// lastRemaining is the rate limit left before the previous cycle, used to estimate its cost
+	lastRemaining int
+
+	// nextDue is when the next repository with its own interval becomes due
+	nextDue time.Time
+}
+
+func (c *CLI) handleWatch(ctx context.Context, opts watchOptions) error {
+	w := &watcher{
+		cli:  c,
+		opts: opts,
+		health: watchHealth{
+			Status:    watchStarting,
+			StartedAt: time.Now(),
+			Interval:  opts.Interval.String(),
+		},
+	}
+
+	var server *http.Server
+	serverErr := make(chan error, 1)
+	if opts.HealthAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/healthz", w.healthHandler())
+		server = &http.Server{
+			Addr:              opts.HealthAddr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		go func() {
+			c.output.Printf("Serving health on %s/healthz\n", opts.HealthAddr)
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErr <- err
+			}
+			close(serverErr)
+		}()
+	}
+
+	c.output.Printf("Watching every %s (jitter up to %s), press Ctrl+C to stop\n", opts.Interval, opts.Jitter)
+	err := w.run(ctx, serverErr)
+
+	if server != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if shutdownErr := server.Shutdown(shutdownCtx); err == nil {
+			err = shutdownErr
+		}
+	}
+
+	return err
+}
+
+// run performs a cycle, then sleeps until the next one, until the context is cancelled.
+// A cycle in progress always completes so the cache is never left half-updated.
+func (w *watcher) run(ctx context.Context, serverErr <-chan error) error {
+	for {
+		w.cycle()
+
+		delay := w.nextDelay()
+		w.mu.Lock()
+		w.health.NextCycleAt = time.Now().Add(delay)
+		w.mu.Unlock()
+		w.writeHealthFile()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			w.cli.output.Println("Stopping watch.")
+			return nil
+		case err := <-serverErr:
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (w *watcher) cycle() {
+	if rateLimit := w.cli.rateLimit(); rateLimit != nil {
+		w.lastRemaining = rateLimit.Remaining
+	}
+
+	start := time.Now()
+	w.cli.output.Printf("\n[%s] Checking repositories...\n", start.Format(time.DateTime))
+	cycle, err := w.cli.runStatusCycle(statusOptions{})
+
+	w.nextDue = time.Time{}
+	if cycle != nil {
+		w.nextDue = cycle.NextDue
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.health.Cycles++
+	w.health.LastCycleAt = start
+	w.health.LastDuration = time.Since(start).Round(time.Millisecond).String()
+	w.health.FailedRepos = nil
+
+	if err == nil && cycle != nil && len(cycle.Failures) == cycle.Repos && cycle.Repos > 0 {
+		err = fmt.Errorf("failed to fetch stats for all %d repositories", cycle.Repos)
+	}
+
+	switch {
+	case err != nil:
+		w.cli.output.Printf("Error: %v\n", err)
+		w.health.Status = watchFailing
+		w.health.ConsecutiveFailures++
+		w.health.LastError = err.Error()
+	case cycle != nil && len(cycle.Failures) > 0:
+		w.health.Status = watchDegraded
+		w.health.ConsecutiveFailures = 0
+		w.health.LastError = ""
+		w.health.LastSuccessAt = start
+		for _, failure := range cycle.Failures {
+			w.health.FailedRepos = append(w.health.FailedRepos, failure.Repo)
+		}
+	default:
+		w.health.Status = watchOK
+		w.health.ConsecutiveFailures = 0
+		w.health.LastError = ""
+		w.health.LastSuccessAt = start
+	}
+
+	if rateLimit := w.cli.rateLimit(); rateLimit != nil && !rateLimit.UpdatedAt.IsZero() {
+		w.health.RateLimit = &rateLimitStatus{
+			Limit:     rateLimit.Limit,
+			Remaining: rateLimit.Remaining,
+			Reset:     rateLimit.Reset,
+		}
+	}
+}
+
+// nextDelay returns the interval plus random jitter, shortened when a repository with its
+// own interval falls due sooner, and extended until the rate limit resets when the previous
+// cycle's cost would not fit in what remains
+func (w *watcher) nextDelay() time.Duration {
+	delay := w.opts.Interval
+	jitter := w.opts.Jitter
+	if untilDue := time.Until(w.nextDue); !w.nextDue.IsZero() && untilDue < delay {
+		delay = max(untilDue, 0)
+		jitter = min(jitter, delay/10)
+	}
+	if jitter > 0 {
+		delay += rand.N(jitter)
+	}
+
+	rateLimit := w.cli.rateLimit()
+	if rateLimit == nil || rateLimit.UpdatedAt.IsZero() {
+		return delay
+	}
+
+	cost := w.lastRemaining - rateLimit.Remaining
+	if cost <= 0 {
+		return delay
+	}
+
+	untilReset := time.Until(rateLimit.Reset)
+	if rateLimit.Remaining < cost && untilReset > delay {
+		w.cli.output.Printf("Rate limit nearly exhausted (%d left, last cycle used %d); waiting for reset at %s\n",
+			rateLimit.Remaining, cost, rateLimit.Reset.Format(time.Kitchen))
+		delay = untilReset
+		if w.opts.Jitter > 0 {
+			delay += rand.N(w.opts.Jitter)
+		}
+	}
+
+	return delay
+}
+
+// Health returns a copy of the current health, marking it failing once no cycle has
+// succeeded for several intervals
+func (w *watcher) Health() watchHealth {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	health := w.health
+	health.FailedRepos = append([]string(nil), w.health.FailedRepos...)
+
+	lastOK := health.LastSuccessAt
+	if lastOK.IsZero() {
+		lastOK = health.StartedAt
+	}
+	if time.Since(lastOK) > staleCycles*(w.opts.Interval+w.opts.Jitter) {
+		health.Status = watchFailing
+	}
+
+	return health
+}
+
+func (w *watcher) healthHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		health := w.Health()
+
+		rw.Header().Set("Content-Type", "application/json")
+		if health.Status == watchFailing {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(rw).Encode(health); err != nil {
+			w.cli.output.Printf("Error writing health: %v\n", err)
+		}
+	})
+}
+
+func (w *watcher) writeHealthFile() {
+	if w.opts.HealthFile == "" {
+		return
+	}
+
+	err := writeFileAtomic(w.opts.HealthFile, func(out io.Writer) error {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(w.Health())
+	})
+	if err != nil {
+		w.cli.output.Printf("Warning: Error writing health file: %v\n", err)
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleWatch_RunsCycleAndWritesHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockConfig := mock_services.NewMockConfigService(ctrl)
+	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
+	mockGitHub := mock_services.NewMockGitHubService(ctrl)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockConfig.EXPECT().Load().Return(&services.Config{Repos: []services.RepoConfig{
+		{Repo: "owner/repo", Events: []string{"stars"}},
+		{Repo: "owner/gone", Events: []string{"stars"}},
+	}}, nil)
+	mockCache.EXPECT().Load().Return(&services.CacheData{Repos: map[string]services.RepoState{}}, nil)
+	mockHistory.EXPECT().Load().Return(&services.HistoryData{}, nil)
+	mockGitHub.EXPECT().GetRepoStats("owner", "repo").Return(&services.RepoStats{Owner: "owner", Name: "repo", Stars: 3}, nil)
+	mockGitHub.EXPECT().GetRepoStats("owner", "gone").Return(nil, services.NewAPIError("not found", 404, "owner/gone", nil))
+	// Stopping during the cycle must still let it persist its state
+	mockCache.EXPECT().Save(gomock.Any()).DoAndReturn(func(*services.CacheData) error {
+		cancel()
+		return nil
+	})
+	mockHistory.EXPECT().Save(gomock.Any()).Return(nil)
+	mockOutput.EXPECT().Printf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockOutput.EXPECT().Println(gomock.Any()).AnyTimes()
+
+	healthFile := filepath.Join(t.TempDir(), "health.json")
+	err := cli.handleWatch(ctx, watchOptions{Interval: time.Hour, HealthFile: healthFile})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	data, err := os.ReadFile(healthFile)
+	if err != nil {
+		t.Fatalf("Expected health file: %v", err)
+	}
+	var health watchHealth
+	if err := json.Unmarshal(data, &health); err != nil {
+		t.Fatalf("Expected JSON health, got %q: %v", data, err)
+	}
+	if health.Status != watchDegraded || health.Cycles != 1 {
+		t.Errorf("Expected one degraded cycle, got %+v", health)
+	}
+	if len(health.FailedRepos) != 1 || health.FailedRepos[0] != "owner/gone" {
+		t.Errorf("Expected owner/gone to be reported as failed, got %v", health.FailedRepos)
+	}
+}
+
+func TestWatcher_HealthHandlerReportsStaleness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cli := &CLI{output: mock_services.NewMockOutput(ctrl)}
+
+	w := &watcher{
+		cli:  cli,
+		opts: watchOptions{Interval: time.Minute},
+		health: watchHealth{
+			Status:        watchOK,
+			StartedAt:     time.Now().Add(-time.Hour),
+			LastSuccessAt: time.Now().Add(-time.Hour),
+		},
+	}
+
+	recorder := httptest.NewRecorder()
+	w.healthHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 for a stale watcher, got %d", recorder.Code)
+	}
+
+	w.health.LastSuccessAt = time.Now()
+	recorder = httptest.NewRecorder()
+	w.healthHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200 for a healthy watcher, got %d", recorder.Code)
+	}
+}
+
+func TestParseWatchArgs(t *testing.T) {
+	opts, err := parseWatchArgs([]string{"--interval=30m", "--health-addr", ":8081"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if opts.Interval != 30*time.Minute || opts.Jitter != 3*time.Minute || opts.HealthAddr != ":8081" {
+		t.Errorf("Unexpected options %+v", opts)
+	}
+
+	if _, err := parseWatchArgs([]string{"--interval", "0s"}); err == nil {
+		t.Error("Expected error for zero interval")
+	}
+}
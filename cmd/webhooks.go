@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+const (
+	// maxWebhookBody matches the largest payload GitHub delivers
+	maxWebhookBody = 25 << 20
+
+	// deliveryRetention is how long delivery IDs are remembered for deduplication
+	deliveryRetention = 72 * time.Hour
+)
+
+// webhookReceiver turns GitHub webhook deliveries into the same events status reports
+type webhookReceiver struct {
+	cli    *CLI
+	secret string
+
+	// mu serializes deliveries, since each one loads and saves the cache and history
+	mu sync.Mutex
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if !services.VerifySignature(r.secret, body, req.Header.Get("X-Hub-Signature-256")) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	event := req.Header.Get("X-GitHub-Event")
+	if event == "ping" {
+		_, _ = fmt.Fprintln(w, "pong")
+		return
+	}
+
+	delivery, err := services.ParseWebhookDelivery(event, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if delivery == nil {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintf(w, "ignored %s event\n", event)
+		return
+	}
+
+	status, message, err := r.process(req.Header.Get("X-GitHub-Delivery"), delivery)
+	if err != nil {
+		r.cli.output.Printf("Error processing %s delivery for %s: %v\n", event, delivery.Repo, err)
+		http.Error(w, "failed to process delivery", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintln(w, message)
+}
+
+// process applies a delivery to the cache and history and notifies, returning the response to send
+func (r *webhookReceiver) process(deliveryID string, delivery *services.WebhookDelivery) (int, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	config, err := r.cli.configService.Load()
+	if err != nil {
+		return 0, "", err
+	}
+
+	repoConfig := config.GetRepo(delivery.Repo)
+	if repoConfig == nil {
+		return http.StatusAccepted, fmt.Sprintf("%s is not watched", delivery.Repo), nil
+	}
+
+	// Only the events the delivery touched are updated; the rest wait for the scheduled poll
+	touched := *repoConfig
+	touched.Events = slices.DeleteFunc(slices.Clone(repoConfig.Events), func(event string) bool {
+		return !slices.Contains(delivery.Events(), event)
+	})
+	if len(touched.Events) == 0 {
+		return http.StatusAccepted, fmt.Sprintf("%s events are not watched for %s", delivery.Event, delivery.Repo), nil
+	}
+
+	cache, err := r.cli.cacheService.Load()
+	if err != nil {
+		return 0, "", err
+	}
+	if cache.Deliveries == nil {
+		cache.Deliveries = make(map[string]time.Time)
+	}
+	if _, seen := cache.Deliveries[deliveryID]; seen && deliveryID != "" {
+		return http.StatusOK, "duplicate delivery", nil
+	}
+
+	history, err := r.cli.historyService.Load()
+	if err != nil {
+		return 0, "", err
+	}
+
+	owner, name, err := services.ParseRepoString(delivery.Repo)
+	if err != nil {
+		return 0, "", err
+	}
+
+	now := time.Now()
+	previous := cache.Repos[delivery.Repo]
+	stats := &services.RepoStats{
+		Owner:        owner,
+		Name:         name,
+		Stars:        delivery.Stars,
+		Issues:       delivery.OpenIssues,
+		PullRequests: max(previous.Count("pull_requests")+delivery.PullRequestDelta, 0),
+		Forks:        delivery.Forks,
+		UpdatedAt:    now,
+	}
+
+	hasChanges := false
+	run := &services.RunResult{CheckedAt: now}
+	processor := &statusProcessor{
+		output:     r.cli.output,
+		cache:      cache,
+		history:    history,
+		run:        run,
+		lastCheck:  cache.LastCheck,
+		checkedAt:  now,
+		hasChanges: &hasChanges,
+		delivery:   true,
+		listIssues: func(string, string, time.Time) ([]services.IssueAPIData, error) {
+			if delivery.Issue == nil {
+				return nil, nil
+			}
+			return []services.IssueAPIData{*delivery.Issue}, nil
+		},
+		fetchEvents: func(stats *services.RepoStats, events []string, previous services.RepoState) (map[string]services.EventState, map[string]error) {
+			states, errs := services.FetchEventStates(context.Background(), nil, stats, events, previous)
+			// Releases normally need the API, but a release delivery carries everything the source needs
+			if delivery.Release != nil && slices.Contains(events, "releases") {
+				states["releases"] = services.ApplyReleaseDelivery(previous.Events["releases"], delivery.Repo, *delivery.Release)
+				delete(errs, "releases")
+			}
+			return states, errs
+		},
+	}
+	if err := processor.ProcessRepo(touched, stats, 0); err != nil {
+		return 0, "", err
+	}
+
+	cache.Deliveries[deliveryID] = now
+	for id, at := range cache.Deliveries {
+		if id == "" || now.Sub(at) > deliveryRetention {
+			delete(cache.Deliveries, id)
+		}
+	}
+
+	if cache.Throttle == nil {
+		cache.Throttle = make(map[string]time.Time)
+	}
+	if hasChanges {
+		r.cli.notify(config, run, cache.Throttle)
+	}
+
+	if err := r.cli.cacheService.Save(cache); err != nil {
+		return 0, "", err
+	}
+	if err := r.cli.historyService.Save(history); err != nil {
+		r.cli.output.Printf("Warning: Error saving history: %v\n", err)
+	}
+
+	return http.StatusAccepted, "processed", nil
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookReceiver_ReplaysRecordedDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockConfig := mock_services.NewMockConfigService(ctrl)
+	mockCache := mock_services.NewMockCacheService(ctrl)
+	mockHistory := mock_services.NewMockHistoryService(ctrl)
+	mockGitHub := mock_services.NewMockGitHubService(ctrl)
+	mockOutput := mock_services.NewMockOutput(ctrl)
+
+	cli := NewCLI(mockConfig, mockCache, mockHistory, mockGitHub, mockOutput)
+	receiver := &webhookReceiver{cli: cli, secret: "s3cret"}
+
+	polledAt := time.Now().Add(-time.Hour)
+	cache := &services.CacheData{Repos: map[string]services.RepoState{
+		"octo-org/widgets": {
+			Events: map[string]services.EventState{
+				"stars": {Count: 42}, "issues": {Count: 7}, "pull_requests": {Count: 2}, "forks": {Count: 5},
+				"releases": {Count: 3, Cursor: "100"},
+			},
+			LastUpdated: polledAt,
+			LastFetched: polledAt,
+		},
+	}}
+	history := &services.HistoryData{}
+
+	mockConfig.EXPECT().Load().Return(&services.Config{Repos: []services.RepoConfig{
+		{Repo: "octo-org/widgets", Events: []string{"stars", "issues", "pull_requests", "releases"}},
+	}}, nil).AnyTimes()
+	mockCache.EXPECT().Load().Return(cache, nil).AnyTimes()
+	mockCache.EXPECT().Save(cache).Return(nil).AnyTimes()
+	mockHistory.EXPECT().Load().Return(history, nil).AnyTimes()
+	mockHistory.EXPECT().Save(history).Return(nil).AnyTimes()
+	mockOutput.EXPECT().Printf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	deliveries := []struct {
+		file   string
+		event  string
+		id     string
+		status int
+	}{
+		{"star_created.json", "star", "d-1", http.StatusAccepted},
+		{"issues_opened.json", "issues", "d-2", http.StatusAccepted},
+		{"pull_request_opened.json", "pull_request", "d-3", http.StatusAccepted},
+		{"pull_request_opened.json", "pull_request", "d-3", http.StatusOK}, // redelivery
+	}
+
+	for _, delivery := range deliveries {
+		body, err := os.ReadFile(filepath.Join("testdata", "webhooks", delivery.file))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", delivery.file, err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+		req.Header.Set("X-GitHub-Event", delivery.event)
+		req.Header.Set("X-GitHub-Delivery", delivery.id)
+		req.Header.Set("X-Hub-Signature-256", services.SignPayload("s3cret", body))
+
+		recorder := httptest.NewRecorder()
+		receiver.ServeHTTP(recorder, req)
+		if recorder.Code != delivery.status {
+			t.Errorf("%s (%s): expected status %d, got %d: %s", delivery.file, delivery.id, delivery.status, recorder.Code, recorder.Body)
+		}
+	}
+
+	state := cache.Repos["octo-org/widgets"]
+	if state.Count("stars") != 43 || state.Count("issues") != 9 || state.Count("pull_requests") != 3 {
+		t.Errorf("Unexpected cached state %+v", state)
+	}
+	if state.Events["releases"].Cursor != "100" {
+		t.Errorf("Expected untouched events to keep their state, got %+v", state.Events["releases"])
+	}
+	if !state.LastFetched.Equal(polledAt) || !state.LastUpdated.Equal(polledAt) {
+		t.Errorf("Expected deliveries not to postpone the scheduled poll, got %+v", state)
+	}
+
+	var types []string
+	for _, event := range history.Events {
+		types = append(types, event.Type)
+	}
+	want := []string{"stars", services.HistoryEventIssue, services.HistoryEventPullRequest}
+	if len(types) != len(want) || types[0] != want[0] || types[1] != want[1] || types[2] != want[2] {
+		t.Errorf("Expected events %v, got %v", want, types)
+	}
+}
+
+func TestWebhookReceiver_RejectsInvalidSignature(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cli := &CLI{output: mock_services.NewMockOutput(ctrl)}
+	receiver := &webhookReceiver{cli: cli, secret: "s3cret"}
+
+	body := []byte(`{"action":"created"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set("X-GitHub-Event", "star")
+	req.Header.Set("X-Hub-Signature-256", services.SignPayload("wrong", body))
+
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", recorder.Code)
+	}
+}
+
+func TestWebhookReceiver_ReleasesUseTheReleasesState(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "webhooks", "release_published.json"))
+	if err != nil {
+		t.Fatalf("Failed to read release_published.json: %v", err)
+	}
+
+	for _, watchReleases := range []bool{true, false} {
+		ctrl := gomock.NewController(t)
+
+		mockConfig := mock_services.NewMockConfigService(ctrl)
+		mockCache := mock_services.NewMockCacheService(ctrl)
+		mockHistory := mock_services.NewMockHistoryService(ctrl)
+		mockOutput := mock_services.NewMockOutput(ctrl)
+
+		cli := NewCLI(mockConfig, mockCache, mockHistory, mock_services.NewMockGitHubService(ctrl), mockOutput)
+		receiver := &webhookReceiver{cli: cli, secret: "s3cret"}
+
+		events := []string{"stars"}
+		if watchReleases {
+			events = append(events, "releases")
+		}
+		cache := &services.CacheData{Repos: map[string]services.RepoState{
+			"octo-org/widgets": {Events: map[string]services.EventState{
+				"stars":    {Count: 42},
+				"releases": {Count: 3, Cursor: "100", Values: map[string]string{"tags": "v1.3.0"}},
+			}},
+		}}
+		history := &services.HistoryData{}
+
+		mockConfig.EXPECT().Load().Return(&services.Config{Repos: []services.RepoConfig{
+			{Repo: "octo-org/widgets", Events: events},
+		}}, nil).AnyTimes()
+		mockCache.EXPECT().Load().Return(cache, nil).AnyTimes()
+		mockCache.EXPECT().Save(cache).Return(nil).AnyTimes()
+		mockHistory.EXPECT().Load().Return(history, nil).AnyTimes()
+		mockHistory.EXPECT().Save(history).Return(nil).AnyTimes()
+		mockOutput.EXPECT().Printf(gomock.Any(), gomock.Any()).AnyTimes()
+
+		// A redelivery under a new ID must not report the release again
+		for _, id := range []string{"d-1", "d-2"} {
+			req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+			req.Header.Set("X-GitHub-Event", "release")
+			req.Header.Set("X-GitHub-Delivery", id)
+			req.Header.Set("X-Hub-Signature-256", services.SignPayload("s3cret", body))
+			receiver.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		state := cache.Repos["octo-org/widgets"].Events["releases"]
+		if !watchReleases {
+			if len(history.Events) != 0 || state.Cursor == "101" {
+				t.Errorf("Expected unwatched releases to be ignored, got %+v and %+v", history.Events, state)
+			}
+			continue
+		}
+
+		if len(history.Events) != 1 || history.Events[0].Type != services.HistoryEventRelease {
+			t.Errorf("Expected one release event, got %+v", history.Events)
+		}
+		if state.Cursor != "101" || state.Count != 4 || state.Values["tags"] != "v1.3.0" {
+			t.Errorf("Expected the releases cursor to advance, got %+v", state)
+		}
+		if _, summary, _ := dashboardLine("releases", cache.Repos["octo-org/widgets"].Events); !strings.HasPrefix(summary, "v1.4.0 ") {
+			t.Errorf("Expected v1.4.0 as the latest release, got %q", summary)
+		}
+	}
+}
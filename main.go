@@ -11,6 +11,7 @@ import (
 func main() {
 	configService := services.NewConfigService()
 	cacheService := services.NewCacheService()
+	historyService := services.NewHistoryService()
 	output := services.NewConsoleOutput()
 
 	githubService, err := services.NewConcurrentGitHubService()
@@ -19,6 +20,6 @@ func main() {
 		os.Exit(1)
 	}
 
-	cli := cmd.NewCLI(configService, cacheService, githubService, output)
+	cli := cmd.NewCLI(configService, cacheService, historyService, githubService, output)
 	cli.Run(os.Args)
 }
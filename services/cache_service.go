@@ -8,6 +8,9 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// StateDirEnv relocates the cache and history, e.g. into a CI workspace persisted with actions/cache
+const StateDirEnv = "GH_OSS_WATCH_STATE_DIR"
+
 type CacheServiceImpl struct{}
 
 func NewCacheService() CacheService {
@@ -77,9 +80,48 @@ func (c *CacheServiceImpl) getCachePath() (string, error) {
 }
 
 func (c *CacheServiceImpl) getConfigDir() (string, error) {
+	if dir := os.Getenv(StateDirEnv); dir != "" {
+		return dir, nil
+	}
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
 	return filepath.Join(homeDir, ".gh-oss-watch"), nil
 }
+
+// legacyEventCounts maps the per-event fields cached before event sources to their event names
+var legacyEventCounts = map[string]string{
+	"last_star_count":  "stars",
+	"last_issue_count": "issues",
+	"last_pr_count":    "pull_requests",
+	"last_fork_count":  "forks",
+}
+
+// UnmarshalYAML reads repository state, migrating counts cached by older versions into Events
+func (s *RepoState) UnmarshalYAML(value *yaml.Node) error {
+	type plain RepoState
+	if err := value.Decode((*plain)(s)); err != nil {
+		return err
+	}
+
+	var legacy map[string]any
+	if err := value.Decode(&legacy); err != nil {
+		return err
+	}
+	for field, event := range legacyEventCounts {
+		count, ok := legacy[field].(int)
+		if !ok {
+			continue
+		}
+		if _, exists := s.Events[event]; exists {
+			continue
+		}
+		if s.Events == nil {
+			s.Events = make(map[string]EventState)
+		}
+		s.Events[event] = EventState{Count: count}
+	}
+
+	return nil
+}
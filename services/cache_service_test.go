@@ -0,0 +1,33 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRepoState_MigratesLegacyCounts(t *testing.T) {
+	legacy := []byte("repos:\n  owner/repo:\n    last_star_count: 120\n    last_issue_count: 4\n    last_pr_count: 2\n    last_fork_count: 9\n")
+
+	var cache services.CacheData
+	if err := yaml.Unmarshal(legacy, &cache); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	state := cache.Repos["owner/repo"]
+	for event, want := range map[string]int{"stars": 120, "issues": 4, "pull_requests": 2, "forks": 9} {
+		if got := state.Count(event); got != want {
+			t.Errorf("Expected %s count %d, got %d", event, want, got)
+		}
+	}
+
+	out, err := yaml.Marshal(&cache)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(string(out), "last_star_count") || !strings.Contains(string(out), "events:") {
+		t.Errorf("Expected cache to be saved in the new format, got:\n%s", out)
+	}
+}
@@ -23,6 +23,26 @@ type RepoResult struct {
 	Stats *RepoStats
 	Index int
 	Error error
+
+	// States and EventErrors are set by GetRepoEventsBatch once the stats were fetched
+	States      map[string]EventState
+	EventErrors map[string]error
+}
+
+// RepoEventsRequest asks for a repository's stats and the state of its watched events
+type RepoEventsRequest struct {
+	Repo     string
+	Events   []string
+	Previous RepoState
+}
+
+// RepoEventsResult is the outcome of a RepoEventsRequest. Error is set when the stats could
+// not be fetched; EventErrors holds the events whose state could not be fetched.
+type RepoEventsResult struct {
+	Stats       *RepoStats
+	Error       error
+	States      map[string]EventState
+	EventErrors map[string]error
 }
 
 func NewConcurrentGitHubService() (BatchGitHubService, error) {
@@ -50,13 +70,65 @@ func (c *ConcurrentGitHubService) GetRepoStatsBatch(repos []string) ([]*RepoStat
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
+	results := c.runBatch(ctx, repos, func(ctx context.Context, job RepoJob) RepoResult {
+		stats, err := c.baseService.GetRepoStats(ctx, job.Owner, job.Repo)
+		return RepoResult{Stats: stats, Index: job.Index, Error: err}
+	})
+
+	stats := make([]*RepoStats, len(repos))
+	errors := make([]error, len(repos))
+	for i, result := range results {
+		stats[i] = result.Stats
+		errors[i] = result.Error
+	}
+
+	return stats, errors
+}
+
+// GetRepoEventsBatch fetches each repository's stats and then its event states in the same
+// worker, so event sources of different repositories are fetched concurrently. The stats
+// request and each event source get their own timeout rather than sharing one for the batch.
+func (c *ConcurrentGitHubService) GetRepoEventsBatch(requests []RepoEventsRequest) []RepoEventsResult {
+	repos := make([]string, len(requests))
+	for i, request := range requests {
+		repos[i] = request.Repo
+	}
+
+	results := c.runBatch(context.Background(), repos, func(ctx context.Context, job RepoJob) RepoResult {
+		statsCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		stats, err := c.baseService.GetRepoStats(statsCtx, job.Owner, job.Repo)
+		cancel()
+		if err != nil {
+			return RepoResult{Index: job.Index, Error: err}
+		}
+
+		request := requests[job.Index]
+		states, errs := c.baseService.FetchEventStates(ctx, stats, request.Events, request.Previous, c.timeout)
+		return RepoResult{Stats: stats, Index: job.Index, States: states, EventErrors: errs}
+	})
+
+	events := make([]RepoEventsResult, len(requests))
+	for i, result := range results {
+		events[i] = RepoEventsResult{
+			Stats:       result.Stats,
+			Error:       result.Error,
+			States:      result.States,
+			EventErrors: result.EventErrors,
+		}
+	}
+	return events
+}
+
+// runBatch runs fetch for each repository using up to maxWorkers workers, returning the
+// results in the order of repos
+func (c *ConcurrentGitHubService) runBatch(ctx context.Context, repos []string, fetch func(ctx context.Context, job RepoJob) RepoResult) []RepoResult {
 	jobs := make(chan RepoJob, len(repos))
 	results := make(chan RepoResult, len(repos))
 
 	var wg sync.WaitGroup
 	for i := 0; i < c.maxWorkers; i++ {
 		wg.Add(1)
-		go c.worker(ctx, &wg, jobs, results)
+		go c.worker(ctx, &wg, jobs, results, fetch)
 	}
 
 	go func() {
@@ -90,20 +162,17 @@ func (c *ConcurrentGitHubService) GetRepoStatsBatch(repos []string) ([]*RepoStat
 		close(results)
 	}()
 
-	stats := make([]*RepoStats, len(repos))
-	errors := make([]error, len(repos))
-
+	ordered := make([]RepoResult, len(repos))
 	for result := range results {
 		if result.Index < len(repos) {
-			stats[result.Index] = result.Stats
-			errors[result.Index] = result.Error
+			ordered[result.Index] = result
 		}
 	}
 
-	return stats, errors
+	return ordered
 }
 
-func (c *ConcurrentGitHubService) worker(ctx context.Context, wg *sync.WaitGroup, jobs <-chan RepoJob, results chan<- RepoResult) {
+func (c *ConcurrentGitHubService) worker(ctx context.Context, wg *sync.WaitGroup, jobs <-chan RepoJob, results chan<- RepoResult, fetch func(ctx context.Context, job RepoJob) RepoResult) {
 	defer wg.Done()
 
 	for {
@@ -113,12 +182,7 @@ func (c *ConcurrentGitHubService) worker(ctx context.Context, wg *sync.WaitGroup
 				return
 			}
 
-			stats, err := c.baseService.GetRepoStats(ctx, job.Owner, job.Repo)
-			results <- RepoResult{
-				Stats: stats,
-				Index: job.Index,
-				Error: err,
-			}
+			results <- fetch(ctx, job)
 
 		case <-ctx.Done():
 			return
@@ -126,6 +190,72 @@ func (c *ConcurrentGitHubService) worker(ctx context.Context, wg *sync.WaitGroup
 	}
 }
 
+func (c *ConcurrentGitHubService) GetRepoActivity(owner, repo string, since time.Time) (*RepoActivity, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	return c.baseService.GetRepoActivity(ctx, owner, repo, since)
+}
+
+func (c *ConcurrentGitHubService) GetNewIssues(owner, repo string, since time.Time) ([]IssueAPIData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	return c.baseService.GetNewIssues(ctx, owner, repo, since)
+}
+
+func (c *ConcurrentGitHubService) FetchEventStates(stats *RepoStats, events []string, previous RepoState) (map[string]EventState, map[string]error) {
+	return c.baseService.FetchEventStates(context.Background(), stats, events, previous, c.timeout)
+}
+
+func (c *ConcurrentGitHubService) UpsertPinnedIssue(owner, repo string, draft IssueDraft) (*IssueAPIData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	return c.baseService.UpsertPinnedIssue(ctx, owner, repo, draft)
+}
+
+func (c *ConcurrentGitHubService) GetNotifications(repos []string) ([]NotificationAPIData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	return c.baseService.GetNotifications(ctx, repos)
+}
+
+// MarkNotificationsRead marks threads as read using up to maxWorkers concurrent requests.
+// Errors are returned in the same order as the thread IDs.
+func (c *ConcurrentGitHubService) MarkNotificationsRead(threadIDs []string) []error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	errs := make([]error, len(threadIDs))
+	sem := make(chan struct{}, c.maxWorkers)
+
+	var wg sync.WaitGroup
+	for i, id := range threadIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			errs[i] = c.baseService.MarkNotificationRead(ctx, id)
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func (c *ConcurrentGitHubService) RateLimit() RateLimitInfo {
+	return c.baseService.RateLimit()
+}
+
 func (c *ConcurrentGitHubService) SetMaxConcurrent(maxConcurrent int) {
 	if maxConcurrent <= 0 {
 		maxConcurrent = 10
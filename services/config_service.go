@@ -3,11 +3,15 @@ package services
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 
 	"gopkg.in/yaml.v3"
 )
 
+// ConfigPathEnv points at a config file to use instead of ~/.gh-oss-watch/config.yaml
+const ConfigPathEnv = "GH_OSS_WATCH_CONFIG"
+
 type ConfigServiceImpl struct{}
 
 func NewConfigService() ConfigService {
@@ -39,17 +43,12 @@ func (c *ConfigServiceImpl) Load() (*Config, error) {
 }
 
 func (c *ConfigServiceImpl) Save(config *Config) error {
-	configDir, err := c.getConfigDir()
+	configPath, err := c.GetConfigPath()
 	if err != nil {
 		return err
 	}
 
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return err
-	}
-
-	configPath, err := c.GetConfigPath()
-	if err != nil {
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
 		return err
 	}
 
@@ -62,6 +61,10 @@ func (c *ConfigServiceImpl) Save(config *Config) error {
 }
 
 func (c *ConfigServiceImpl) GetConfigPath() (string, error) {
+	if path := os.Getenv(ConfigPathEnv); path != "" {
+		return path, nil
+	}
+
 	configDir, err := c.getConfigDir()
 	if err != nil {
 		return "", err
@@ -77,22 +80,6 @@ func (c *ConfigServiceImpl) getConfigDir() (string, error) {
 	return filepath.Join(homeDir, ".gh-oss-watch"), nil
 }
 
-func validateEvents(events []string) error {
-	validEvents := map[string]bool{
-		"stars":         true,
-		"issues":        true,
-		"pull_requests": true,
-		"forks":         true,
-	}
-
-	for _, event := range events {
-		if !validEvents[event] {
-			return fmt.Errorf("invalid event type: %s", event)
-		}
-	}
-	return nil
-}
-
 func (c *Config) AddRepo(repo string, events []string) error {
 	if err := validateEvents(events); err != nil {
 		return err
@@ -128,3 +115,18 @@ func (c *Config) RemoveRepo(repo string) error {
 	}
 	return fmt.Errorf("repository %s not found in config", repo)
 }
+
+// InGroup reports whether a repository matches one of the group's patterns
+func (c *Config) InGroup(repo, group string) bool {
+	return MatchRepoPatterns(c.Groups[group], repo)
+}
+
+// MatchRepoPatterns reports whether a repository matches any glob pattern such as "myorg/*"
+func MatchRepoPatterns(patterns []string, repo string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, repo); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const emailHTMLTemplate = `<html><body style="font-family: sans-serif">
+<h2>OSS Watch activity</h2>
+{{range .Changed}}<h3>📈 {{.Repo}}</h3>
+<ul>{{range .ChangeLines}}<li>{{.}}</li>{{end}}</ul>
+{{with .Activity}}<ul>{{range .}}{{if .URL}}<li><a href="{{.URL}}">#{{.Number}} {{.Title}}</a>{{with .Author}} by @{{.}}{{end}}</li>{{end}}{{end}}</ul>{{end}}
+{{else}}<p>No new activity since last check.</p>
+{{end}}{{with .Failed}}<h3>⚠️ Errors</h3>
+<ul>{{range .}}<li>{{.Repo}}: {{.Error}}</li>{{end}}</ul>
+{{end}}<p style="color: #666">Checked at {{.CheckedAt.Format "2006-01-02 15:04 MST"}} by gh-oss-watch</p>
+</body></html>`
+
+// EmailNotifier sends a multipart text and HTML digest of the run over SMTP
+type EmailNotifier struct {
+	config      EmailConfig
+	html        *htmltemplate.Template
+	retryConfig RetryConfig
+}
+
+func NewEmailNotifier(config EmailConfig) (*EmailNotifier, error) {
+	if config.Host == "" || config.From == "" || len(config.To) == 0 {
+		return nil, NewConfigError(fmt.Sprintf("email %s: host, from and to are required", config.Name), nil)
+	}
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	if config.Subject == "" {
+		config.Subject = "OSS Watch digest"
+	}
+
+	return &EmailNotifier{
+		config:      config,
+		html:        htmltemplate.Must(htmltemplate.New("email").Parse(emailHTMLTemplate)),
+		retryConfig: DefaultRetryConfig(),
+	}, nil
+}
+
+func (e *EmailNotifier) Name() string {
+	return e.config.Name
+}
+
+func (e *EmailNotifier) Notify(ctx context.Context, run *RunResult) error {
+	if e.config.OnlyOnChanges && !run.HasChanges() {
+		return nil
+	}
+
+	message, err := e.buildMessage(run)
+	if err != nil {
+		return err
+	}
+
+	return WithRetry(ctx, e.retryConfig, func() error {
+		return e.send(ctx, message)
+	})
+}
+
+func (e *EmailNotifier) buildMessage(run *RunResult) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	textPart, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := textPart.Write([]byte(emailText(run))); err != nil {
+		return nil, err
+	}
+
+	htmlPart, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/html; charset=utf-8"}})
+	if err != nil {
+		return nil, err
+	}
+	if err := e.html.Execute(htmlPart, run); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	subject := e.config.Subject
+	if changed := len(run.Changed()); changed > 0 {
+		subject = fmt.Sprintf("%s: activity in %d repositories", subject, changed)
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", e.config.From)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(e.config.To, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", writer.Boundary())
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
+
+func emailText(run *RunResult) string {
+	var b strings.Builder
+
+	changed := run.Changed()
+	if len(changed) == 0 {
+		b.WriteString("No new activity since last check.\n")
+	}
+	for _, repo := range changed {
+		fmt.Fprintf(&b, "📈 %s:\n", repo.Repo)
+		for _, line := range repo.ChangeLines() {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+		for _, event := range repo.Activity {
+			if event.URL != "" {
+				fmt.Fprintf(&b, "  - #%d %s (%s)\n", event.Number, event.Title, event.URL)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	if failed := run.Failed(); len(failed) > 0 {
+		b.WriteString("⚠️ Errors:\n")
+		for _, repo := range failed {
+			fmt.Fprintf(&b, "  %s: %s\n", repo.Repo, repo.Error)
+		}
+	}
+
+	return b.String()
+}
+
+func (e *EmailNotifier) send(ctx context.Context, message []byte) error {
+	addr := net.JoinHostPort(e.config.Host, strconv.Itoa(e.config.Port))
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return NewNetworkError(fmt.Sprintf("email %s: failed to connect to %s", e.config.Name, addr), "", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, e.config.Host)
+	if err != nil {
+		_ = conn.Close()
+		return NewNetworkError(fmt.Sprintf("email %s: SMTP handshake failed", e.config.Name), "", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if !e.config.DisableStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return NewConfigError(fmt.Sprintf("email %s: server does not support STARTTLS", e.config.Name), nil)
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: e.config.Host}); err != nil {
+			return NewNetworkError(fmt.Sprintf("email %s: STARTTLS failed", e.config.Name), "", err)
+		}
+	}
+
+	if e.config.UsernameEnv != "" {
+		username, password := os.Getenv(e.config.UsernameEnv), os.Getenv(e.config.PasswordEnv)
+		if username == "" || password == "" {
+			return NewConfigError(fmt.Sprintf("email %s: credentials not set in %s/%s", e.config.Name, e.config.UsernameEnv, e.config.PasswordEnv), nil)
+		}
+		if err := client.Auth(smtp.PlainAuth("", username, password, e.config.Host)); err != nil {
+			return NewConfigError(fmt.Sprintf("email %s: authentication failed", e.config.Name), err)
+		}
+	}
+
+	if err := client.Mail(e.config.From); err != nil {
+		return fmt.Errorf("email %s: %w", e.config.Name, err)
+	}
+	for _, recipient := range e.config.To {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("email %s: recipient %s rejected: %w", e.config.Name, recipient, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("email %s: %w", e.config.Name, err)
+	}
+	if _, err := writer.Write(message); err != nil {
+		return NewNetworkError(fmt.Sprintf("email %s: failed to send message", e.config.Name), "", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("email %s: %w", e.config.Name, err)
+	}
+
+	return client.Quit()
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -16,6 +17,7 @@ const (
 	ErrorTypeValidation ErrorType = "validation"
 	ErrorTypeTimeout    ErrorType = "timeout"
 	ErrorTypeRateLimit  ErrorType = "rate_limit"
+	ErrorTypeUnknown    ErrorType = "unknown"
 )
 
 // GitHubError represents a structured error with context
@@ -53,6 +55,15 @@ func (e *GitHubError) IsRetryable() bool {
 	}
 }
 
+// ErrorTypeOf returns the category of an error, or ErrorTypeUnknown if it is not a GitHubError
+func ErrorTypeOf(err error) ErrorType {
+	var ghErr *GitHubError
+	if errors.As(err, &ghErr) {
+		return ghErr.Type
+	}
+	return ErrorTypeUnknown
+}
+
 // NewAPIError creates a new API-related error
 func NewAPIError(message string, statusCode int, repo string, underlying error) *GitHubError {
 	errorType := ErrorTypeAPI
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNoAPIClient is returned by event sources that need the GitHub API when only repository stats are available
+var ErrNoAPIClient = errors.New("event source requires the GitHub API")
+
+// EventState is what an event source remembers about a repository between runs
+type EventState struct {
+	Count  int               `yaml:"count" json:"count"`
+	Cursor string            `yaml:"cursor,omitempty" json:"cursor,omitempty"`
+	Values map[string]string `yaml:"values,omitempty" json:"values,omitempty"`
+
+	// New holds items a fetch found since the previous state; it is reported as the change and never stored
+	New []HistoryEvent `yaml:"-" json:"-"`
+
+	// Data holds source-specific data fetched alongside the state for HistoryMerger; it is never stored
+	Data any `yaml:"-" json:"-"`
+}
+
+// EventChange is what an event source found new since the stored state
+type EventChange struct {
+	Count int
+	Items []HistoryEvent
+}
+
+// FetchRequest carries what an event source may use to read the current state of a repository
+type FetchRequest struct {
+	Repo  string
+	Owner string
+	Name  string
+	Stats *RepoStats
+
+	// Previous is the stored state, useful as a cursor for incremental fetches
+	Previous EventState
+
+	// Client is nil when only repository stats are available, e.g. while handling webhooks
+	Client GitHubAPIClient
+}
+
+// EventSource is a kind of activity that can be watched, such as "stars"
+type EventSource interface {
+	// Name is the event name used in config, cache and rules
+	Name() string
+	// Title labels the event in headings, e.g. "Stars"
+	Title() string
+	// Emoji marks the event in one-line descriptions, e.g. "⭐"
+	Emoji() string
+	// Fetch reads the current state for a repository. Sources that report items compare
+	// it with req.Previous and return the items found since in EventState.New.
+	Fetch(ctx context.Context, req FetchRequest) (EventState, error)
+	// Render describes a change for people, e.g. "+3 stars (120 total)"; current is nil when unknown
+	Render(change EventChange, current *EventState) string
+}
+
+// Differ is implemented by event sources that report a change by comparing counts rather
+// than through EventState.New, such as stars
+type Differ interface {
+	// Diff reports what is new in current compared to previous
+	Diff(previous, current EventState) EventChange
+}
+
+// DashboardSummarizer is implemented by event sources with a line on the dashboard
+type DashboardSummarizer interface {
+	// DashboardLine summarizes a fetched state, e.g. "Latest Release" and "v1.2.0 (3 days ago)".
+	// It returns false when the state has nothing to show.
+	DashboardLine(state EventState) (label, summary string, ok bool)
+}
+
+// DashboardTotaler is implemented by dashboard sources whose summary adds up across repositories
+type DashboardTotaler interface {
+	// DashboardTotal returns what a state adds to the total shown under the DashboardLine label
+	DashboardTotal(state EventState) (int, bool)
+}
+
+// HistoryMerger is implemented by event sources that archive what they fetch in the history store
+type HistoryMerger interface {
+	MergeHistory(history *HistoryData, repo string, state EventState, at time.Time)
+}
+
+// EventSourceLinker is implemented by event sources with a page on GitHub listing their items
+type EventSourceLinker interface {
+	URL(repo string) string
+}
+
+// ItemDescriber is implemented by event sources that report items, to title them in the feed
+type ItemDescriber interface {
+	// DescribeItem titles an item the source reported, e.g. "🚀 owner/repo released v1.2.0".
+	// It returns false for items reported by other sources.
+	DescribeItem(item HistoryEvent) (string, bool)
+}
+
+// ChangeDetailer is implemented by event sources whose items need a line each under the change,
+// such as links to comments waiting for a reply
+type ChangeDetailer interface {
+	Details(change EventChange) []string
+}
+
+var (
+	eventSources       = map[string]EventSource{}
+	eventSourceOrder   []string
+	defaultEventSource = map[string]bool{}
+)
+
+// RegisterEventSource makes an event source available by name. Sources registered as
+// default are watched when a repository is added without an explicit event list.
+func RegisterEventSource(source EventSource, watchByDefault bool) {
+	name := source.Name()
+	if _, exists := eventSources[name]; exists {
+		panic(fmt.Sprintf("event source %q registered twice", name))
+	}
+
+	eventSources[name] = source
+	eventSourceOrder = append(eventSourceOrder, name)
+	defaultEventSource[name] = watchByDefault
+}
+
+// LookupEventSource returns the event source registered under name
+func LookupEventSource(name string) (EventSource, bool) {
+	source, ok := eventSources[name]
+	return source, ok
+}
+
+// EventSourceNames lists all registered event names in registration order
+func EventSourceNames() []string {
+	return append([]string(nil), eventSourceOrder...)
+}
+
+// DefaultEvents lists the events watched when none are given
+func DefaultEvents() []string {
+	var names []string
+	for _, name := range eventSourceOrder {
+		if defaultEventSource[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// FetchEventStates fetches the state of each watched event. Errors are reported per event
+// so that one unavailable source does not hide the others.
+func FetchEventStates(ctx context.Context, client GitHubAPIClient, stats *RepoStats, events []string, previous RepoState) (map[string]EventState, map[string]error) {
+	return fetchEventStates(ctx, client, stats, events, previous, 0)
+}
+
+// fetchEventStates is FetchEventStates giving each source its own timeout, unless sourceTimeout is zero,
+// so that one slow source cannot use up the time of the sources after it
+func fetchEventStates(ctx context.Context, client GitHubAPIClient, stats *RepoStats, events []string, previous RepoState, sourceTimeout time.Duration) (map[string]EventState, map[string]error) {
+	states := make(map[string]EventState, len(events))
+	var errs map[string]error
+
+	for _, event := range events {
+		source, ok := LookupEventSource(event)
+		if !ok {
+			continue
+		}
+
+		sourceCtx, cancel := ctx, context.CancelFunc(func() {})
+		if sourceTimeout > 0 {
+			sourceCtx, cancel = context.WithTimeout(ctx, sourceTimeout)
+		}
+		state, err := source.Fetch(sourceCtx, FetchRequest{
+			Repo:     stats.Owner + "/" + stats.Name,
+			Owner:    stats.Owner,
+			Name:     stats.Name,
+			Stats:    stats,
+			Previous: previous.Events[event],
+			Client:   client,
+		})
+		cancel()
+		if err != nil {
+			if errs == nil {
+				errs = make(map[string]error)
+			}
+			errs[event] = err
+			continue
+		}
+		states[event] = state
+	}
+
+	return states, errs
+}
+
+// CalculateEventSummary compares fetched event states with the stored state to determine changes
+func CalculateEventSummary(repo string, current map[string]EventState, previous RepoState) (EventSummary, map[string]EventChange) {
+	summary := EventSummary{Repo: repo}
+	changes := make(map[string]EventChange)
+
+	for event, state := range current {
+		source, ok := LookupEventSource(event)
+		if !ok {
+			continue
+		}
+
+		change := DiffEventState(source, previous.Events[event], state)
+		if change.Count <= 0 {
+			continue
+		}
+
+		if summary.Changes == nil {
+			summary.Changes = make(map[string]int)
+		}
+		summary.Changes[event] = change.Count
+		summary.HasChanges = true
+		changes[event] = change
+	}
+
+	return summary, changes
+}
+
+// DiffEventState reports what a source found new in current: the items its fetch
+// returned in New, or for a Differ the result of comparing current with previous
+func DiffEventState(source EventSource, previous, current EventState) EventChange {
+	if differ, ok := source.(Differ); ok {
+		return differ.Diff(previous, current)
+	}
+	return EventChange{Count: len(current.New), Items: current.New}
+}
+
+// Delta returns the number of new items for an event, such as new stars
+func (s EventSummary) Delta(event string) int {
+	return s.Changes[event]
+}
+
+// DescribeChange renders a change with its emoji, e.g. "⭐ +3 stars (120 total)"
+func DescribeChange(event string, change EventChange, current *EventState) string {
+	source, ok := LookupEventSource(event)
+	if !ok {
+		return fmt.Sprintf("%s: +%d", event, change.Count)
+	}
+	return source.Emoji() + " " + source.Render(change, current)
+}
+
+// DescribeItem titles an item using the event source that reported it
+func DescribeItem(item HistoryEvent) (string, bool) {
+	for _, name := range eventSourceOrder {
+		if describer, ok := eventSources[name].(ItemDescriber); ok {
+			if title, ok := describer.DescribeItem(item); ok {
+				return title, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ChangeDetails returns the lines an event source shows under a change, if any
+func ChangeDetails(event string, change EventChange) []string {
+	source, ok := LookupEventSource(event)
+	if !ok {
+		return nil
+	}
+	if detailer, ok := source.(ChangeDetailer); ok {
+		return detailer.Details(change)
+	}
+	return nil
+}
+
+// Count returns the stored count for an event
+func (s RepoState) Count(event string) int {
+	return s.Events[event].Count
+}
+
+// validateEvents checks that every event has a registered source
+func validateEvents(events []string) error {
+	for _, event := range events {
+		if _, ok := LookupEventSource(event); !ok {
+			names := EventSourceNames()
+			sort.Strings(names)
+			return fmt.Errorf("invalid event type: %s (valid: %s)", event, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
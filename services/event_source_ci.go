@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Item types reported by the ci source
+const (
+	HistoryEventCIFailed = "ci_failed"
+	HistoryEventCIFixed  = "ci_fixed"
+)
+
+// ciItemRedSince is the HistoryEvent.Values key holding when a workflow started failing
+const ciItemRedSince = "red_since"
+
+// Workflow conclusions, as reported by the GitHub Actions API
+const (
+	ciPassing = "passing"
+	ciFailing = "failing"
+)
+
+// WorkflowStatus is the last known outcome of a workflow on a repository's default branch
+type WorkflowStatus struct {
+	Name    string
+	Passing bool
+	URL     string
+
+	// RedSince is when the workflow started failing; zero while it passes
+	RedSince time.Time
+}
+
+// ciSource watches GitHub Actions workflow runs on the default branch and reports when a
+// workflow starts failing or is fixed. Its state holds each workflow's status, keyed by
+// workflow ID, and its cursor the branch that was checked.
+type ciSource struct{}
+
+func init() {
+	RegisterEventSource(ciSource{}, false)
+}
+
+func (ciSource) Name() string  { return "ci" }
+func (ciSource) Title() string { return "CI" }
+func (ciSource) Emoji() string { return "🚦" }
+
+func (ciSource) URL(repo string) string {
+	return "https://github.com/" + repo + "/actions"
+}
+
+func (ciSource) Fetch(ctx context.Context, req FetchRequest) (EventState, error) {
+	if req.Client == nil {
+		return EventState{}, ErrNoAPIClient
+	}
+
+	branch := req.Stats.DefaultBranch
+	if branch == "" {
+		repoData, err := req.Client.GetRepoData(ctx, req.Owner, req.Name)
+		if err != nil {
+			return EventState{}, err
+		}
+		branch = repoData.DefaultBranch
+	}
+
+	runs, err := req.Client.GetWorkflowRuns(ctx, req.Owner, req.Name, branch)
+	if err != nil {
+		return EventState{}, err
+	}
+
+	// The first fetch for a repository is the baseline, so workflows already failing are not reported
+	baseline := req.Previous.Cursor == ""
+	state := EventState{Cursor: branch, Values: map[string]string{}}
+
+	seen := map[string]bool{}
+	for _, workflow := range latestWorkflowStatuses(runs) {
+		seen[workflow.id] = true
+		previous, known := workflowStatus(req.Previous, workflow.id)
+		if !workflow.Passing && known && !previous.Passing && previous.RedSince.Before(workflow.RedSince) {
+			workflow.RedSince = previous.RedSince
+		}
+
+		switch {
+		case baseline:
+		case !workflow.Passing && (!known || previous.Passing):
+			state.New = append(state.New, newCIEvent(req.Repo, HistoryEventCIFailed, workflow))
+		case workflow.Passing && known && !previous.Passing:
+			workflow.RedSince = previous.RedSince
+			state.New = append(state.New, newCIEvent(req.Repo, HistoryEventCIFixed, workflow))
+			workflow.RedSince = time.Time{}
+		}
+
+		if !workflow.Passing {
+			state.Count++
+		}
+		setWorkflowStatus(&state, workflow.id, workflow.WorkflowStatus)
+	}
+
+	// Workflows that ran rarely may be missing from the recent runs, so their last known
+	// status is kept until they run again on the same branch
+	if req.Previous.Cursor == branch {
+		for key := range req.Previous.Values {
+			id, ok := strings.CutPrefix(key, "status/")
+			if !ok || seen[id] {
+				continue
+			}
+			previous, _ := workflowStatus(req.Previous, id)
+			if !previous.Passing {
+				state.Count++
+			}
+			setWorkflowStatus(&state, id, previous)
+		}
+	}
+
+	return state, nil
+}
+
+type workflowRunStatus struct {
+	WorkflowStatus
+	id    string
+	runAt time.Time
+}
+
+// latestWorkflowStatuses reduces runs, newest first, to the latest outcome per workflow.
+// Cancelled and skipped runs say nothing about health and are passed over.
+func latestWorkflowStatuses(runs []WorkflowRunAPIData) []workflowRunStatus {
+	var statuses []workflowRunStatus
+	index := map[int64]int{}
+	settled := map[int64]bool{}
+
+	for _, run := range runs {
+		var passing bool
+		switch run.Conclusion {
+		case "success":
+			passing = true
+		case "failure", "timed_out", "startup_failure":
+			passing = false
+		default:
+			continue
+		}
+
+		i, seen := index[run.WorkflowID]
+		if !seen {
+			index[run.WorkflowID] = len(statuses)
+			status := workflowRunStatus{
+				WorkflowStatus: WorkflowStatus{Name: run.Name, Passing: passing, URL: run.HTMLURL},
+				id:             strconv.FormatInt(run.WorkflowID, 10),
+				runAt:          run.CreatedAt,
+			}
+			if !passing {
+				status.RedSince = run.CreatedAt
+			}
+			statuses = append(statuses, status)
+			settled[run.WorkflowID] = passing
+			continue
+		}
+
+		// Walk back through the failing streak to find when the workflow went red
+		if settled[run.WorkflowID] {
+			continue
+		}
+		if passing {
+			settled[run.WorkflowID] = true
+			continue
+		}
+		statuses[i].RedSince = run.CreatedAt
+	}
+
+	return statuses
+}
+
+func workflowStatus(state EventState, id string) (WorkflowStatus, bool) {
+	status, ok := state.Values["status/"+id]
+	if !ok {
+		return WorkflowStatus{}, false
+	}
+
+	redSince, _ := time.Parse(time.RFC3339, state.Values["red_since/"+id])
+	return WorkflowStatus{
+		Name:     state.Values["name/"+id],
+		Passing:  status == ciPassing,
+		URL:      state.Values["url/"+id],
+		RedSince: redSince,
+	}, true
+}
+
+func setWorkflowStatus(state *EventState, id string, status WorkflowStatus) {
+	state.Values["name/"+id] = status.Name
+	state.Values["url/"+id] = status.URL
+	state.Values["status/"+id] = ciPassing
+	if !status.Passing {
+		state.Values["status/"+id] = ciFailing
+		state.Values["red_since/"+id] = status.RedSince.Format(time.RFC3339)
+	}
+}
+
+func newCIEvent(repo, eventType string, workflow workflowRunStatus) HistoryEvent {
+	event := HistoryEvent{
+		ID:    fmt.Sprintf("%s/%s/%s/%d", repo, eventType, workflow.id, workflow.runAt.Unix()),
+		Repo:  repo,
+		Type:  eventType,
+		Time:  workflow.runAt,
+		Title: workflow.Name,
+		URL:   workflow.URL,
+	}
+	if !workflow.RedSince.IsZero() {
+		event.Values = map[string]string{ciItemRedSince: workflow.RedSince.Format(time.RFC3339)}
+	}
+	return event
+}
+
+// Render lists workflow transitions, e.g. "+2 CI changes: Nightly failing (red 3h), Tests fixed after 2d red"
+func (ciSource) Render(change EventChange, _ *EventState) string {
+	noun := "CI changes"
+	if change.Count == 1 {
+		noun = "CI change"
+	}
+	if len(change.Items) == 0 {
+		return fmt.Sprintf("+%d %s", change.Count, noun)
+	}
+
+	items := make([]string, len(change.Items))
+	for i, item := range change.Items {
+		items[i] = describeCIEvent(item, time.Now())
+	}
+	return fmt.Sprintf("+%d %s: %s", change.Count, noun, strings.Join(items, ", "))
+}
+
+func describeCIEvent(event HistoryEvent, now time.Time) string {
+	redSince, err := time.Parse(time.RFC3339, event.Values[ciItemRedSince])
+	if event.Type == HistoryEventCIFixed {
+		if err != nil {
+			return event.Title + " fixed"
+		}
+		return fmt.Sprintf("%s fixed after %s red", event.Title, shortDuration(event.Time.Sub(redSince)))
+	}
+	if err != nil {
+		return event.Title + " failing"
+	}
+	return fmt.Sprintf("%s failing (red %s)", event.Title, shortDuration(now.Sub(redSince)))
+}
+
+func (ciSource) DescribeItem(item HistoryEvent) (string, bool) {
+	switch item.Type {
+	case HistoryEventCIFailed:
+		return fmt.Sprintf("❌ %s: %s failing on the default branch", item.Repo, item.Title), true
+	case HistoryEventCIFixed:
+		return fmt.Sprintf("✅ %s: %s fixed", item.Repo, item.Title), true
+	}
+	return "", false
+}
+
+// shortDuration rounds a duration for people, e.g. "45m", "5h" or "3d"
+func shortDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// DashboardLine summarizes default-branch CI, e.g. "❌ Nightly (red since 3 days ago)" or "✅ 4 workflows passing"
+func (ciSource) DashboardLine(state EventState) (string, string, bool) {
+	workflows := ciStatus(state)
+	if len(workflows) == 0 {
+		return "CI", "no workflow runs", true
+	}
+
+	var failing []string
+	for _, workflow := range workflows {
+		if !workflow.Passing {
+			failing = append(failing, fmt.Sprintf("%s (red since %s)", workflow.Name, formatAge(time.Since(workflow.RedSince))))
+		}
+	}
+	if len(failing) > 0 {
+		return "CI", "❌ " + strings.Join(failing, ", "), true
+	}
+	return "CI", fmt.Sprintf("✅ %d workflows passing", len(workflows)), true
+}
+
+// ciStatus returns the workflows recorded in a ci state, failing ones first
+func ciStatus(state EventState) []WorkflowStatus {
+	var statuses []WorkflowStatus
+	for key := range state.Values {
+		id, ok := strings.CutPrefix(key, "status/")
+		if !ok {
+			continue
+		}
+		if status, ok := workflowStatus(state, id); ok {
+			statuses = append(statuses, status)
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Passing != statuses[j].Passing {
+			return !statuses[i].Passing
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
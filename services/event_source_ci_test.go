@@ -0,0 +1,96 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCISource_KeepsWorkflowsMissingFromRecentRuns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	now := time.Now()
+	nightly := func(id int64, conclusion string, at time.Time) services.WorkflowRunAPIData {
+		return services.WorkflowRunAPIData{ID: id, Name: "Nightly", WorkflowID: 1, Conclusion: conclusion, CreatedAt: at}
+	}
+	tests := func(id int64, at time.Time) services.WorkflowRunAPIData {
+		return services.WorkflowRunAPIData{ID: id, Name: "Tests", WorkflowID: 2, Conclusion: "success", CreatedAt: at}
+	}
+
+	gomock.InOrder(
+		mockClient.EXPECT().GetWorkflowRuns(gomock.Any(), "owner", "repo", "main").
+			Return([]services.WorkflowRunAPIData{tests(2, now.Add(-72*time.Hour)), nightly(1, "success", now.Add(-96*time.Hour))}, nil),
+		mockClient.EXPECT().GetWorkflowRuns(gomock.Any(), "owner", "repo", "main").
+			Return([]services.WorkflowRunAPIData{nightly(3, "failure", now.Add(-48*time.Hour)), tests(2, now.Add(-72*time.Hour))}, nil),
+		// Nightly drops out of the window behind a busy day of test runs
+		mockClient.EXPECT().GetWorkflowRuns(gomock.Any(), "owner", "repo", "main").
+			Return([]services.WorkflowRunAPIData{tests(4, now.Add(-2*time.Hour))}, nil),
+		mockClient.EXPECT().GetWorkflowRuns(gomock.Any(), "owner", "repo", "main").
+			Return([]services.WorkflowRunAPIData{nightly(5, "success", now.Add(-time.Hour)), tests(4, now.Add(-2*time.Hour))}, nil),
+	)
+
+	stats := &services.RepoStats{Owner: "owner", Name: "repo", DefaultBranch: "main"}
+	fetch := func(previous services.EventState) services.EventState {
+		return fetchEventState(t, "ci", mockClient, stats, previous)
+	}
+
+	state := fetch(services.EventState{})
+	previous := state
+	state = fetch(state)
+	if len(state.New) != 1 || state.New[0].Type != services.HistoryEventCIFailed {
+		t.Fatalf("Expected Nightly to be reported failing, got %+v", state.New)
+	}
+
+	state = fetch(state)
+	if len(state.New) != 0 || state.Count != 1 {
+		t.Fatalf("Expected Nightly to be kept failing while missing from the runs, got %+v", state)
+	}
+
+	previous = state
+	state = fetch(state)
+	if len(state.New) != 1 || state.New[0].Type != services.HistoryEventCIFixed || state.Count != 0 {
+		t.Fatalf("Expected Nightly to be reported fixed, got %+v", state)
+	}
+	want := "+1 CI change: Nightly fixed after 47h red"
+	if got := renderEventState("ci", previous, state); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestCISource_ReportsTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	now := time.Now()
+	redSince := now.Add(-72 * time.Hour)
+	runs := []services.WorkflowRunAPIData{
+		{ID: 6, Name: "Nightly", WorkflowID: 1, Conclusion: "failure", HTMLURL: "https://github.com/owner/repo/actions/runs/6", CreatedAt: now.Add(-time.Hour)},
+		{ID: 5, Name: "Tests", WorkflowID: 2, Conclusion: "success", CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: 4, Name: "Nightly", WorkflowID: 1, Conclusion: "cancelled", CreatedAt: now.Add(-20 * time.Hour)},
+		{ID: 3, Name: "Nightly", WorkflowID: 1, Conclusion: "failure", CreatedAt: now.Add(-25 * time.Hour)},
+		{ID: 2, Name: "Nightly", WorkflowID: 1, Conclusion: "success", CreatedAt: now.Add(-49 * time.Hour)},
+	}
+	mockClient.EXPECT().GetWorkflowRuns(gomock.Any(), "owner", "repo", "main").Return(runs, nil)
+
+	previous := services.EventState{Count: 1, Cursor: "main", Values: map[string]string{
+		"name/1": "Nightly", "status/1": "passing",
+		"name/2": "Tests", "status/2": "failing", "red_since/2": redSince.Format(time.RFC3339),
+	}}
+	state := fetchEventState(t, "ci", mockClient, &services.RepoStats{Owner: "owner", Name: "repo", DefaultBranch: "main"}, previous)
+
+	want := "+2 CI changes: Nightly failing (red 25h), Tests fixed after 2d red"
+	if got := renderEventState("ci", previous, state); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if len(state.New) != 2 || state.New[0].Type != services.HistoryEventCIFailed || state.New[1].Type != services.HistoryEventCIFixed {
+		t.Errorf("Expected a failed and a fixed CI event, got %+v", state.New)
+	}
+
+	if want := "❌ Nightly (red since yesterday)"; dashboardSummary("ci", state) != want {
+		t.Errorf("Expected %q, got %q", want, dashboardSummary("ci", state))
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// HistoryEventFirstContribution is the item type reported by the contributors source
+const HistoryEventFirstContribution = "first_contribution"
+
+// contributorsKnown is the EventState.Values key holding the contributor logins seen so far,
+// least recently merged first
+const contributorsKnown = "known"
+
+// maxKnownContributors bounds how many logins the contributors source remembers. Forgotten
+// contributors are searched for again when they are next merged, so they are never misreported.
+const maxKnownContributors = 500
+
+// contributorsSource reports first-time contributors whose pull requests were merged since
+// the previous fetch. Authors are compared against the stored set of known contributors
+// first, and only unknown authors cost a search for their earlier merged pull requests.
+// Its cursor is the time of the previous fetch and its count the number of contributors
+// seen, including those no longer remembered.
+type contributorsSource struct{}
+
+func init() {
+	RegisterEventSource(contributorsSource{}, false)
+}
+
+func (contributorsSource) Name() string  { return "contributors" }
+func (contributorsSource) Title() string { return "Contributors" }
+func (contributorsSource) Emoji() string { return "🎉" }
+
+func (contributorsSource) URL(repo string) string {
+	return "https://github.com/" + repo + "/graphs/contributors"
+}
+
+func (contributorsSource) Fetch(ctx context.Context, req FetchRequest) (EventState, error) {
+	if req.Client == nil {
+		return EventState{}, ErrNoAPIClient
+	}
+
+	// Taken before the requests so pull requests merged while they run are seen next time
+	now := time.Now()
+
+	var known []string
+	if value := req.Previous.Values[contributorsKnown]; value != "" {
+		known = strings.Split(value, "\n")
+	}
+	state := EventState{Cursor: now.Format(time.RFC3339), Values: map[string]string{}}
+
+	// The first fetch for a repository is the baseline, so earlier contributors are not reported.
+	// They are seeded from the top contributors, who then never cost a search.
+	since, err := time.Parse(time.RFC3339, req.Previous.Cursor)
+	if err != nil {
+		contributors, err := req.Client.GetContributors(ctx, req.Owner, req.Name)
+		if err != nil {
+			return EventState{}, err
+		}
+		for _, contributor := range contributors {
+			if contributor.Login != "" && !slices.Contains(known, contributor.Login) {
+				known = append(known, contributor.Login)
+			}
+		}
+		state.Count = len(known)
+		state.Values[contributorsKnown] = strings.Join(known[max(len(known)-maxKnownContributors, 0):], "\n")
+		return state, nil
+	}
+
+	merged, err := req.Client.GetMergedPullRequestsSince(ctx, req.Owner, req.Name, since)
+	if err != nil {
+		return EventState{}, err
+	}
+
+	// Oldest first, so a contributor's first pull request is the one reported
+	slices.SortFunc(merged, func(a, b PullRequestAPIData) int { return a.MergedAt.Compare(*b.MergedAt) })
+
+	mergedBy := map[string]int{}
+	for _, pr := range merged {
+		mergedBy[pr.User.Login]++
+	}
+
+	state.Count = req.Previous.Count
+	for _, pr := range merged {
+		login := pr.User.Login
+		if login == "" || strings.HasSuffix(login, "[bot]") {
+			continue
+		}
+		// Known contributors move to the end, so the least recently merged are forgotten first
+		if i := slices.Index(known, login); i >= 0 {
+			known = append(slices.Delete(known, i, i+1), login)
+			continue
+		}
+
+		// When the search fails it is unknown whether this is a first contribution, so the
+		// author is left unknown and searched for again the next time they are merged
+		total, err := req.Client.CountMergedPullRequests(ctx, req.Owner, req.Name, login)
+		if err != nil {
+			continue
+		}
+		known = append(known, login)
+		state.Count++
+		if total <= mergedBy[login] {
+			state.New = append(state.New, newFirstContributionEvent(req.Repo, pr))
+		}
+	}
+
+	state.Values[contributorsKnown] = strings.Join(known[max(len(known)-maxKnownContributors, 0):], "\n")
+	return state, nil
+}
+
+func newFirstContributionEvent(repo string, pr PullRequestAPIData) HistoryEvent {
+	return HistoryEvent{
+		ID:     fmt.Sprintf("%s/%s/%s", repo, HistoryEventFirstContribution, pr.User.Login),
+		Repo:   repo,
+		Type:   HistoryEventFirstContribution,
+		Time:   *pr.MergedAt,
+		Number: pr.Number,
+		Title:  pr.Title,
+		URL:    pr.HTMLURL,
+		Author: pr.User.Login,
+	}
+}
+
+func (contributorsSource) DescribeItem(item HistoryEvent) (string, bool) {
+	if item.Type != HistoryEventFirstContribution {
+		return "", false
+	}
+	return fmt.Sprintf("🎉 %s: first contribution from @%s (#%d %s)", item.Repo, item.Author, item.Number, item.Title), true
+}
+
+// Render names the new contributors, e.g. "+2 first-time contributors: @alice (#12), @bob (#15)"
+func (contributorsSource) Render(change EventChange, _ *EventState) string {
+	noun := "first-time contributors"
+	if change.Count == 1 {
+		noun = "first-time contributor"
+	}
+	if len(change.Items) == 0 {
+		return fmt.Sprintf("+%d %s", change.Count, noun)
+	}
+
+	names := make([]string, len(change.Items))
+	for i, item := range change.Items {
+		names[i] = fmt.Sprintf("@%s (#%d)", item.Author, item.Number)
+	}
+	return fmt.Sprintf("+%d %s: %s", change.Count, noun, strings.Join(names, ", "))
+}
@@ -0,0 +1,117 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestContributorsSource_SeedsKnownContributors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	mergedAt := time.Now().Add(-time.Minute)
+	mockClient.EXPECT().GetContributors(gomock.Any(), "owner", "repo").
+		Return([]services.UserAPIData{{Login: "alice"}, {Login: "bob"}}, nil)
+	mockClient.EXPECT().GetMergedPullRequestsSince(gomock.Any(), "owner", "repo", gomock.Any()).
+		Return([]services.PullRequestAPIData{{Number: 12, User: services.UserAPIData{Login: "alice"}, MergedAt: &mergedAt}}, nil)
+
+	state := fetchEventState(t, "contributors", mockClient, nil, services.EventState{})
+	if state.Count != 2 || state.Values["known"] != "alice\nbob" {
+		t.Fatalf("Expected the baseline to know the contributors, got %+v", state)
+	}
+
+	// A seeded contributor is not searched for
+	if state = fetchEventState(t, "contributors", mockClient, nil, state); len(state.New) != 0 {
+		t.Errorf("Expected nothing new, got %+v", state.New)
+	}
+}
+
+func TestContributorsSource_ContinuesWhenSearchFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	now := time.Now()
+	pr := func(number int, login string, mergedAt time.Time) services.PullRequestAPIData {
+		return services.PullRequestAPIData{Number: number, User: services.UserAPIData{Login: login}, MergedAt: &mergedAt}
+	}
+	mockClient.EXPECT().GetMergedPullRequestsSince(gomock.Any(), "owner", "repo", gomock.Any()).
+		Return([]services.PullRequestAPIData{pr(13, "carol", now.Add(-time.Hour)), pr(12, "bob", now.Add(-2*time.Hour))}, nil)
+	mockClient.EXPECT().CountMergedPullRequests(gomock.Any(), "owner", "repo", "bob").Return(0, errors.New("search rate limited"))
+	mockClient.EXPECT().CountMergedPullRequests(gomock.Any(), "owner", "repo", "carol").Return(1, nil)
+
+	// The search failure does not fail the fetch
+	previous := services.EventState{Count: 1, Cursor: now.Add(-24 * time.Hour).Format(time.RFC3339), Values: map[string]string{"known": "alice"}}
+	state := fetchEventState(t, "contributors", mockClient, nil, previous)
+	if len(state.New) != 1 || state.New[0].Author != "carol" {
+		t.Errorf("Expected carol to be reported, got %+v", state.New)
+	}
+	if state.Values["known"] != "alice\ncarol" {
+		t.Errorf("Expected bob to stay unknown, got %q", state.Values["known"])
+	}
+}
+
+func TestContributorsSource_ReportsFirstTimeContributors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	now := time.Now()
+	pr := func(number int, login string, mergedAt time.Time) services.PullRequestAPIData {
+		return services.PullRequestAPIData{Number: number, Title: "Fix typo", User: services.UserAPIData{Login: login}, MergedAt: &mergedAt}
+	}
+	mockClient.EXPECT().GetMergedPullRequestsSince(gomock.Any(), "owner", "repo", gomock.Any()).Return([]services.PullRequestAPIData{
+		pr(15, "bob", now.Add(-time.Hour)),
+		pr(14, "dependabot[bot]", now.Add(-2*time.Hour)),
+		pr(13, "carol", now.Add(-3*time.Hour)),
+		pr(12, "alice", now.Add(-4*time.Hour)),
+		pr(11, "bob", now.Add(-5*time.Hour)),
+	}, nil)
+	mockClient.EXPECT().CountMergedPullRequests(gomock.Any(), "owner", "repo", "bob").Return(2, nil)
+	mockClient.EXPECT().CountMergedPullRequests(gomock.Any(), "owner", "repo", "carol").Return(4, nil)
+
+	previous := services.EventState{Count: 1, Cursor: now.Add(-24 * time.Hour).Format(time.RFC3339), Values: map[string]string{"known": "alice"}}
+	state := fetchEventState(t, "contributors", mockClient, nil, previous)
+
+	want := "+1 first-time contributor: @bob (#11)"
+	if got := renderEventState("contributors", previous, state); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	// bob was merged again after carol, so carol would be forgotten first
+	if state.Count != 3 || state.Values["known"] != "alice\ncarol\nbob" {
+		t.Errorf("Expected alice, carol and bob to be known, got %+v", state)
+	}
+}
+
+func TestContributorsSource_BoundsKnownContributors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	known := make([]string, 600)
+	for i := range known {
+		known[i] = fmt.Sprintf("user%d", i)
+	}
+	mergedAt := time.Now().Add(-time.Hour)
+	mockClient.EXPECT().GetMergedPullRequestsSince(gomock.Any(), "owner", "repo", gomock.Any()).Return([]services.PullRequestAPIData{
+		{Number: 12, User: services.UserAPIData{Login: "newcomer"}, MergedAt: &mergedAt},
+		{Number: 11, User: services.UserAPIData{Login: "user0"}, MergedAt: &mergedAt},
+	}, nil)
+	mockClient.EXPECT().CountMergedPullRequests(gomock.Any(), "owner", "repo", "newcomer").Return(1, nil)
+
+	previous := services.EventState{Count: 600, Cursor: time.Now().Add(-24 * time.Hour).Format(time.RFC3339), Values: map[string]string{"known": strings.Join(known, "\n")}}
+	state := fetchEventState(t, "contributors", mockClient, nil, previous)
+
+	remembered := strings.Split(state.Values["known"], "\n")
+	if len(remembered) != 500 || !slices.Contains(remembered, "user0") || !slices.Contains(remembered, "newcomer") || slices.Contains(remembered, "user1") {
+		t.Errorf("Expected the 500 most recently merged contributors to be remembered, got %d", len(remembered))
+	}
+	if state.Count != 601 {
+		t.Errorf("Expected 601 contributors seen, got %d", state.Count)
+	}
+}
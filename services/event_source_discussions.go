@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Item types reported by the discussions source
+const (
+	HistoryEventDiscussion        = "discussion"
+	HistoryEventDiscussionComment = "discussion_comment"
+)
+
+// Keys of the HistoryEvent.Values of discussion items
+const (
+	discussionItemCategory   = "category"
+	discussionItemUnanswered = "unanswered"
+)
+
+// discussionsUnanswered is the EventState.Values key holding the number of unanswered questions
+const discussionsUnanswered = "unanswered"
+
+// discussionsPageSize is how many recently updated discussions are inspected per fetch
+const discussionsPageSize = 50
+
+// discussionCommentsPageSize is how many of a discussion's latest comments are inspected, with
+// the latest reply in each of their threads
+const discussionCommentsPageSize = 10
+
+// discussionCategoriesPageSize is how many discussion categories are looked up for questions;
+// GitHub allows at most 25 per repository
+const discussionCategoriesPageSize = 25
+
+const discussionsQuery = `query($owner: String!, $name: String!, $first: Int!, $comments: Int!, $categories: Int!) {
+  repository(owner: $owner, name: $name) {
+    discussions(first: $first, orderBy: {field: UPDATED_AT, direction: DESC}) {
+      totalCount
+      nodes {
+        number
+        title
+        url
+        createdAt
+        updatedAt
+        isAnswered
+        author { login }
+        category { name isAnswerable }
+        comments(last: $comments) {
+          totalCount
+          nodes {
+            createdAt
+            author { login }
+            replies(last: 1) {
+              nodes { createdAt author { login } }
+            }
+          }
+        }
+      }
+    }
+    discussionCategories(first: $categories) {
+      nodes { id isAnswerable }
+    }
+  }
+}`
+
+const unansweredQuery = `query($owner: String!, $name: String!, $categoryId: ID!) {
+  repository(owner: $owner, name: $name) {
+    discussions(categoryId: $categoryId, answered: false) { totalCount }
+  }
+}`
+
+type discussionsResponse struct {
+	Repository struct {
+		Discussions struct {
+			TotalCount int              `json:"totalCount"`
+			Nodes      []discussionNode `json:"nodes"`
+		} `json:"discussions"`
+		DiscussionCategories struct {
+			Nodes []struct {
+				ID           string `json:"id"`
+				IsAnswerable bool   `json:"isAnswerable"`
+			} `json:"nodes"`
+		} `json:"discussionCategories"`
+	} `json:"repository"`
+}
+
+type unansweredResponse struct {
+	Repository struct {
+		Discussions struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"discussions"`
+	} `json:"repository"`
+}
+
+type discussionNode struct {
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	IsAnswered bool      `json:"isAnswered"`
+	Author     struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Category struct {
+		Name         string `json:"name"`
+		IsAnswerable bool   `json:"isAnswerable"`
+	} `json:"category"`
+	Comments struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			discussionComment
+			Replies struct {
+				Nodes []discussionComment `json:"nodes"`
+			} `json:"replies"`
+		} `json:"nodes"`
+	} `json:"comments"`
+}
+
+type discussionComment struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Author    struct {
+		Login string `json:"login"`
+	} `json:"author"`
+}
+
+// latestComment returns the newest of the listed comments and thread replies
+func (d discussionNode) latestComment() (discussionComment, bool) {
+	var latest discussionComment
+	found := false
+	for _, comment := range d.Comments.Nodes {
+		candidates := append([]discussionComment{comment.discussionComment}, comment.Replies.Nodes...)
+		for _, candidate := range candidates {
+			if !found || candidate.CreatedAt.After(latest.CreatedAt) {
+				latest, found = candidate, true
+			}
+		}
+	}
+	return latest, found
+}
+
+// unanswered reports whether the discussion is a question still waiting for an accepted answer
+func (d discussionNode) unanswered() bool {
+	return d.Category.IsAnswerable && !d.IsAnswered
+}
+
+// discussionsSource watches GitHub Discussions through GraphQL, since REST cannot list them.
+// Its cursor is the newest updatedAt seen, as GitHub's clock rather than ours; discussions
+// created, commented on or replied to after it are reported.
+type discussionsSource struct{}
+
+func init() {
+	RegisterEventSource(discussionsSource{}, false)
+}
+
+func (discussionsSource) Name() string  { return "discussions" }
+func (discussionsSource) Title() string { return "Discussions" }
+func (discussionsSource) Emoji() string { return "💬" }
+
+func (discussionsSource) URL(repo string) string {
+	return "https://github.com/" + repo + "/discussions"
+}
+
+func (discussionsSource) Fetch(ctx context.Context, req FetchRequest) (EventState, error) {
+	if req.Client == nil {
+		return EventState{}, ErrNoAPIClient
+	}
+
+	var response discussionsResponse
+	variables := map[string]any{
+		"owner": req.Owner, "name": req.Name,
+		"first": discussionsPageSize, "comments": discussionCommentsPageSize, "categories": discussionCategoriesPageSize,
+	}
+	if err := req.Client.GraphQL(ctx, discussionsQuery, variables, &response); err != nil {
+		return EventState{}, err
+	}
+
+	// Only recent discussions are listed, so unanswered questions are counted per question category
+	unanswered := 0
+	for _, category := range response.Repository.DiscussionCategories.Nodes {
+		if !category.IsAnswerable {
+			continue
+		}
+		var count unansweredResponse
+		variables := map[string]any{"owner": req.Owner, "name": req.Name, "categoryId": category.ID}
+		if err := req.Client.GraphQL(ctx, unansweredQuery, variables, &count); err != nil {
+			return EventState{}, err
+		}
+		unanswered += count.Repository.Discussions.TotalCount
+	}
+
+	// The first fetch for a repository is the baseline, so existing discussions are not reported
+	since, err := time.Parse(time.RFC3339, req.Previous.Cursor)
+	baseline := err != nil
+
+	discussions := response.Repository.Discussions
+	cursor := since
+	var newItems []HistoryEvent
+	for _, discussion := range discussions.Nodes {
+		if discussion.UpdatedAt.After(cursor) {
+			cursor = discussion.UpdatedAt
+		}
+		if baseline || !discussion.UpdatedAt.After(since) {
+			continue
+		}
+
+		if discussion.CreatedAt.After(since) {
+			newItems = append(newItems, newDiscussionEvent(req.Repo, discussion, HistoryEventDiscussion, discussion.CreatedAt, discussion.Author.Login))
+			continue
+		}
+		if comment, ok := discussion.latestComment(); ok && comment.CreatedAt.After(since) {
+			newItems = append(newItems, newDiscussionEvent(req.Repo, discussion, HistoryEventDiscussionComment, comment.CreatedAt, comment.Author.Login))
+		}
+	}
+
+	return EventState{
+		Count:  discussions.TotalCount,
+		Cursor: cursor.Format(time.RFC3339),
+		Values: map[string]string{discussionsUnanswered: strconv.Itoa(unanswered)},
+		New:    newItems,
+	}, nil
+}
+
+func newDiscussionEvent(repo string, discussion discussionNode, eventType string, at time.Time, author string) HistoryEvent {
+	id := fmt.Sprintf("%s/%s/%d", repo, eventType, discussion.Number)
+	if eventType == HistoryEventDiscussionComment {
+		id = fmt.Sprintf("%s/%d", id, at.Unix())
+	}
+
+	values := map[string]string{}
+	if discussion.Category.Name != "" {
+		values[discussionItemCategory] = discussion.Category.Name
+	}
+	if discussion.unanswered() {
+		values[discussionItemUnanswered] = "true"
+	}
+
+	return HistoryEvent{
+		ID:     id,
+		Repo:   repo,
+		Type:   eventType,
+		Time:   at,
+		Number: discussion.Number,
+		Title:  discussion.Title,
+		URL:    discussion.URL,
+		Author: author,
+		Values: values,
+	}
+}
+
+// Render lists new discussions and discussions with new comments, e.g.
+// "+2 discussions: #12 Install fails [Q&A, unanswered], new comments on #9 Roadmap [Ideas] (4 unanswered questions)"
+func (discussionsSource) Render(change EventChange, current *EventState) string {
+	noun := "discussions"
+	if change.Count == 1 {
+		noun = "discussion"
+	}
+
+	line := fmt.Sprintf("+%d %s", change.Count, noun)
+	if len(change.Items) > 0 {
+		items := make([]string, len(change.Items))
+		for i, item := range change.Items {
+			items[i] = describeDiscussion(item)
+		}
+		line += ": " + strings.Join(items, ", ")
+	}
+
+	if current != nil {
+		if unanswered, ok := unansweredQuestions(*current); ok && unanswered > 0 {
+			line += fmt.Sprintf(" (%d unanswered questions)", unanswered)
+		}
+	}
+	return line
+}
+
+func describeDiscussion(event HistoryEvent) string {
+	description := fmt.Sprintf("#%d %s", event.Number, event.Title)
+	if event.Type == HistoryEventDiscussionComment {
+		description = "new comments on " + description
+	}
+
+	labels := []string{}
+	if category := event.Values[discussionItemCategory]; category != "" {
+		labels = append(labels, category)
+	}
+	if event.Values[discussionItemUnanswered] == "true" {
+		labels = append(labels, "unanswered")
+	}
+	if len(labels) > 0 {
+		description += " [" + strings.Join(labels, ", ") + "]"
+	}
+	return description
+}
+
+func (discussionsSource) DescribeItem(item HistoryEvent) (string, bool) {
+	switch item.Type {
+	case HistoryEventDiscussion:
+		return fmt.Sprintf("💬 %s#%d: %s", item.Repo, item.Number, item.Title), true
+	case HistoryEventDiscussionComment:
+		return fmt.Sprintf("💬 %s#%d: new comments on %s", item.Repo, item.Number, item.Title), true
+	}
+	return "", false
+}
+
+func (discussionsSource) DashboardLine(state EventState) (string, string, bool) {
+	unanswered, ok := unansweredQuestions(state)
+	return "Unanswered Questions", strconv.Itoa(unanswered), ok
+}
+
+func (discussionsSource) DashboardTotal(state EventState) (int, bool) {
+	return unansweredQuestions(state)
+}
+
+// unansweredQuestions returns how many discussions are questions without an accepted answer
+func unansweredQuestions(state EventState) (int, bool) {
+	value, ok := state.Values[discussionsUnanswered]
+	if !ok {
+		return 0, false
+	}
+	unanswered, err := strconv.Atoi(value)
+	return unanswered, err == nil
+}
@@ -0,0 +1,63 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDiscussionsSource_ReportsDiscussions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	since := time.Now().Add(-time.Hour)
+	at := func(d time.Duration) string { return since.Add(d).Format(time.RFC3339) }
+	response := fmt.Sprintf(`{"repository": {"discussions": {"totalCount": 40, "nodes": [
+		{"number": 12, "title": "Install fails", "createdAt": %q, "updatedAt": %q, "isAnswered": false,
+		 "author": {"login": "newcomer"}, "category": {"name": "Q&A", "isAnswerable": true}, "comments": {"totalCount": 0, "nodes": []}},
+		{"number": 9, "title": "Roadmap", "createdAt": %q, "updatedAt": %q,
+		 "category": {"name": "Ideas"}, "comments": {"totalCount": 5, "nodes": [
+			{"createdAt": %q, "author": {"login": "bob"}, "replies": {"nodes": [{"createdAt": %q, "author": {"login": "carol"}}]}},
+			{"createdAt": %q, "author": {"login": "alice"}, "replies": {"nodes": []}}
+		 ]}},
+		{"number": 3, "title": "Old question", "createdAt": %q, "updatedAt": %q, "isAnswered": false,
+		 "category": {"name": "Q&A", "isAnswerable": true}, "comments": {"totalCount": 0, "nodes": []}}
+	]}, "discussionCategories": {"nodes": [{"id": "DIC_qa", "isAnswerable": true}, {"id": "DIC_ideas"}]}}}`, at(10*time.Minute), at(10*time.Minute), at(-48*time.Hour), at(30*time.Minute), at(-24*time.Hour), at(30*time.Minute), at(20*time.Minute), at(-72*time.Hour), at(-72*time.Hour))
+
+	mockClient.EXPECT().GraphQL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ map[string]any, out any) error {
+			return json.Unmarshal([]byte(response), out)
+		})
+	// Unanswered questions are counted across all discussions, not only the recent ones
+	mockClient.EXPECT().GraphQL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, variables map[string]any, out any) error {
+			if variables["categoryId"] != "DIC_qa" {
+				t.Errorf("Expected only the Q&A category to be counted, got %v", variables["categoryId"])
+			}
+			return json.Unmarshal([]byte(`{"repository": {"discussions": {"totalCount": 7}}}`), out)
+		})
+
+	previous := services.EventState{Count: 38, Cursor: since.Format(time.RFC3339)}
+	state := fetchEventState(t, "discussions", mockClient, nil, previous)
+
+	want := "+2 discussions: #12 Install fails [Q&A, unanswered], new comments on #9 Roadmap [Ideas] (7 unanswered questions)"
+	if got := renderEventState("discussions", previous, state); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	// The reply in an older thread is newer than the last comment
+	if len(state.New) != 2 || state.New[1].Type != services.HistoryEventDiscussionComment || state.New[1].Author != "carol" {
+		t.Errorf("Expected a new discussion and a reply, got %+v", state.New)
+	}
+	if want := at(30 * time.Minute); state.Cursor != want {
+		t.Errorf("Expected the newest updatedAt %s as the cursor, got %s", want, state.Cursor)
+	}
+	if dashboardSummary("discussions", state) != "7" || state.Count != 40 {
+		t.Errorf("Expected 40 discussions with 7 unanswered questions, got %+v", state)
+	}
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Item types reported by the mentions source
+const (
+	HistoryEventMention = "mention"
+	HistoryEventReply   = "reply"
+)
+
+// mentionsSource reports issue and pull request comments that @-mention the authenticated
+// user, or that reply on threads the user authored or is assigned to. Unlike the
+// notification inbox it only covers watched repositories. Its cursor is the time of the
+// previous fetch, and its count is the running total of comments reported.
+type mentionsSource struct{}
+
+func init() {
+	RegisterEventSource(mentionsSource{}, false)
+}
+
+func (mentionsSource) Name() string  { return "mentions" }
+func (mentionsSource) Title() string { return "Mentions" }
+func (mentionsSource) Emoji() string { return "📣" }
+
+func (mentionsSource) Fetch(ctx context.Context, req FetchRequest) (EventState, error) {
+	if req.Client == nil {
+		return EventState{}, ErrNoAPIClient
+	}
+
+	// Taken before the requests so comments created while they run are seen next time
+	now := time.Now()
+	state := EventState{Count: req.Previous.Count, Cursor: now.Format(time.RFC3339)}
+
+	// The first fetch for a repository is the baseline, so earlier comments are not reported
+	since, err := time.Parse(time.RFC3339, req.Previous.Cursor)
+	if err != nil {
+		return state, nil
+	}
+
+	viewer, err := req.Client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return EventState{}, err
+	}
+
+	comments, err := req.Client.GetIssueCommentsSince(ctx, req.Owner, req.Name, since)
+	if err != nil {
+		return EventState{}, err
+	}
+	if len(comments) == 0 {
+		return state, nil
+	}
+
+	// Comments only reference their thread, so look up titles, authors and assignees
+	threads, err := req.Client.GetIssuesUpdatedSince(ctx, req.Owner, req.Name, since)
+	if err != nil {
+		return EventState{}, err
+	}
+	byURL := make(map[string]IssueAPIData, len(threads))
+	for _, thread := range threads {
+		byURL[thread.URL] = thread
+	}
+
+	// Oldest first, matching the order they happened in
+	slices.SortFunc(comments, func(a, b IssueCommentAPIData) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	for _, comment := range comments {
+		if strings.EqualFold(comment.User.Login, viewer.Login) {
+			continue
+		}
+		thread, ok := byURL[comment.IssueURL]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case mentionsLogin(comment.Body, viewer.Login):
+			state.New = append(state.New, newMentionEvent(req.Repo, HistoryEventMention, thread, comment))
+		case strings.HasSuffix(comment.User.Login, "[bot]"):
+			continue
+		case strings.EqualFold(thread.User.Login, viewer.Login) || slices.ContainsFunc(thread.Assignees, func(u UserAPIData) bool {
+			return strings.EqualFold(u.Login, viewer.Login)
+		}):
+			state.New = append(state.New, newMentionEvent(req.Repo, HistoryEventReply, thread, comment))
+		}
+	}
+
+	state.Count += len(state.New)
+	return state, nil
+}
+
+// mentionsLogin reports whether body @-mentions login. Logins may contain hyphens, so a
+// mention only ends where the next character could not be part of a login.
+func mentionsLogin(body, login string) bool {
+	target := "@" + strings.ToLower(login)
+	body = strings.ToLower(body)
+
+	for offset := 0; ; {
+		i := strings.Index(body[offset:], target)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(target)
+		offset = end
+
+		if start > 0 && isLoginChar(body[start-1]) {
+			continue
+		}
+		if end < len(body) && isLoginChar(body[end]) {
+			continue
+		}
+		return true
+	}
+}
+
+func isLoginChar(c byte) bool {
+	return c == '-' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+}
+
+func newMentionEvent(repo, eventType string, thread IssueAPIData, comment IssueCommentAPIData) HistoryEvent {
+	return HistoryEvent{
+		ID:     fmt.Sprintf("%s/%s/%s", repo, eventType, strconv.FormatInt(comment.ID, 10)),
+		Repo:   repo,
+		Type:   eventType,
+		Time:   comment.CreatedAt,
+		Number: thread.Number,
+		Title:  thread.Title,
+		URL:    comment.HTMLURL,
+		Author: comment.User.Login,
+	}
+}
+
+func (mentionsSource) DescribeItem(item HistoryEvent) (string, bool) {
+	switch item.Type {
+	case HistoryEventMention:
+		return fmt.Sprintf("📣 %s#%d: @%s mentioned you on %s", item.Repo, item.Number, item.Author, item.Title), true
+	case HistoryEventReply:
+		return fmt.Sprintf("📣 %s#%d: @%s replied on %s", item.Repo, item.Number, item.Author, item.Title), true
+	}
+	return "", false
+}
+
+// Details links each comment, since mentions are waiting for a reply
+func (mentionsSource) Details(change EventChange) []string {
+	lines := make([]string, len(change.Items))
+	for i, item := range change.Items {
+		lines[i] = fmt.Sprintf("#%d %s: %s", item.Number, item.Title, item.URL)
+	}
+	return lines
+}
+
+// Render lists who mentioned or replied where, e.g. "+2 mentions: @alice mentioned you on #12, @bob replied on #9"
+func (mentionsSource) Render(change EventChange, _ *EventState) string {
+	noun := "mentions"
+	if change.Count == 1 {
+		noun = "mention"
+	}
+
+	line := fmt.Sprintf("+%d %s", change.Count, noun)
+	if len(change.Items) > 0 {
+		items := make([]string, len(change.Items))
+		for i, item := range change.Items {
+			items[i] = describeMention(item)
+		}
+		line += ": " + strings.Join(items, ", ")
+	}
+	return line
+}
+
+// describeMention describes a mention or reply, e.g. "@alice mentioned you on #12"
+func describeMention(event HistoryEvent) string {
+	if event.Type == HistoryEventReply {
+		return fmt.Sprintf("@%s replied on #%d", event.Author, event.Number)
+	}
+	return fmt.Sprintf("@%s mentioned you on #%d", event.Author, event.Number)
+}
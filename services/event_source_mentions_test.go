@@ -0,0 +1,51 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMentionsSource_ReportsMentionsAndReplies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	now := time.Now()
+	comment := func(id int64, login, body, issueURL string, createdAt time.Time) services.IssueCommentAPIData {
+		return services.IssueCommentAPIData{
+			ID: id, Body: body, IssueURL: issueURL, CreatedAt: createdAt,
+			HTMLURL: fmt.Sprintf("https://github.com/owner/repo/issues/1#issuecomment-%d", id),
+			User:    services.UserAPIData{Login: login},
+		}
+	}
+	mockClient.EXPECT().GetAuthenticatedUser(gomock.Any()).Return(&services.UserAPIData{Login: "me"}, nil)
+	mockClient.EXPECT().GetIssueCommentsSince(gomock.Any(), "owner", "repo", gomock.Any()).Return([]services.IssueCommentAPIData{
+		comment(4, "carol", "Thanks!", "api/9", now.Add(-10*time.Minute)),
+		comment(3, "me", "Looking into it", "api/12", now.Add(-20*time.Minute)),
+		comment(2, "bob", "cc @meme and @me-too", "api/7", now.Add(-30*time.Minute)),
+		comment(1, "alice", "@Me could you review?", "api/12", now.Add(-40*time.Minute)),
+	}, nil)
+	mockClient.EXPECT().GetIssuesUpdatedSince(gomock.Any(), "owner", "repo", gomock.Any()).Return([]services.IssueAPIData{
+		{Number: 12, Title: "Crash on start", URL: "api/12", User: services.UserAPIData{Login: "alice"}},
+		{Number: 9, Title: "Docs", URL: "api/9", User: services.UserAPIData{Login: "dave"}, Assignees: []services.UserAPIData{{Login: "me"}}},
+		{Number: 7, Title: "Roadmap", URL: "api/7", User: services.UserAPIData{Login: "bob"}},
+	}, nil)
+
+	previous := services.EventState{Count: 5, Cursor: now.Add(-time.Hour).Format(time.RFC3339)}
+	state := fetchEventState(t, "mentions", mockClient, nil, previous)
+
+	want := "+2 mentions: @alice mentioned you on #12, @carol replied on #9"
+	if got := renderEventState("mentions", previous, state); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if len(state.New) != 2 || state.New[0].Type != services.HistoryEventMention || state.New[1].Type != services.HistoryEventReply {
+		t.Errorf("Expected a mention and a reply, got %+v", state.New)
+	}
+	if state.Count != 7 {
+		t.Errorf("Expected the running total to be 7, got %d", state.Count)
+	}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryEventMetadataChange is the item type reported by the metadata source
+const HistoryEventMetadataChange = "metadata_change"
+
+// metadataFields lists the watched repository settings in the order changes are reported.
+// Each is stored as a string in EventState.Values under its name.
+var metadataFields = []struct {
+	name  string
+	value func(m *RepoMetadata) string
+}{
+	{"archived", func(m *RepoMetadata) string { return strconv.FormatBool(m.Archived) }},
+	{"disabled", func(m *RepoMetadata) string { return strconv.FormatBool(m.Disabled) }},
+	{"visibility", func(m *RepoMetadata) string { return m.Visibility }},
+	{"default_branch", func(m *RepoMetadata) string { return m.DefaultBranch }},
+	{"license", func(m *RepoMetadata) string { return m.License }},
+	{"has_issues", func(m *RepoMetadata) string { return strconv.FormatBool(m.HasIssues) }},
+	{"has_discussions", func(m *RepoMetadata) string { return strconv.FormatBool(m.HasDiscussions) }},
+	{"description", func(m *RepoMetadata) string { return m.Description }},
+	{"homepage", func(m *RepoMetadata) string { return m.Homepage }},
+	{"topics", func(m *RepoMetadata) string {
+		topics := slices.Clone(m.Topics)
+		slices.Sort(topics)
+		return strings.Join(topics, ",")
+	}},
+}
+
+// metadataSource reports changes to repository settings such as visibility, archiving or
+// the default branch. It reads them from the repository stats, so it needs no extra API
+// calls, and its count is the running total of changes reported.
+type metadataSource struct{}
+
+func init() {
+	RegisterEventSource(metadataSource{}, false)
+}
+
+func (metadataSource) Name() string  { return "metadata" }
+func (metadataSource) Title() string { return "Metadata" }
+func (metadataSource) Emoji() string { return "📝" }
+
+func (metadataSource) URL(repo string) string {
+	return "https://github.com/" + repo + "/settings"
+}
+
+func (metadataSource) Fetch(_ context.Context, req FetchRequest) (EventState, error) {
+	metadata := req.Stats.Metadata
+	if metadata == nil {
+		return EventState{}, ErrNoAPIClient
+	}
+
+	now := time.Now()
+	state := EventState{Count: req.Previous.Count, Values: make(map[string]string, len(metadataFields))}
+	for _, field := range metadataFields {
+		state.Values[field.name] = field.value(metadata)
+	}
+
+	// The first fetch for a repository is the baseline, so current settings are not reported
+	if req.Previous.Values == nil {
+		return state, nil
+	}
+
+	for _, field := range metadataFields {
+		from, known := req.Previous.Values[field.name]
+		to := state.Values[field.name]
+		// Settings added after the previous fetch have no stored value to compare with
+		if !known || from == to {
+			continue
+		}
+		state.New = append(state.New, HistoryEvent{
+			ID:    fmt.Sprintf("%s/%s/%s/%d", req.Repo, HistoryEventMetadataChange, field.name, now.Unix()),
+			Repo:  req.Repo,
+			Type:  HistoryEventMetadataChange,
+			Time:  now,
+			Title: describeMetadataChange(field.name, from, to),
+			URL:   "https://github.com/" + req.Repo,
+		})
+	}
+
+	state.Count += len(state.New)
+	return state, nil
+}
+
+// describeMetadataChange describes a setting change, e.g. `visibility: public → private`
+// or `description: "" → "A CLI"`
+func describeMetadataChange(field, from, to string) string {
+	switch field {
+	case "archived", "disabled", "has_issues", "has_discussions", "visibility", "default_branch", "license":
+		return fmt.Sprintf("%s: %s → %s", field, metadataValue(from), metadataValue(to))
+	default:
+		return fmt.Sprintf("%s: %q → %q", field, from, to)
+	}
+}
+
+func metadataValue(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+func (metadataSource) DescribeItem(item HistoryEvent) (string, bool) {
+	if item.Type != HistoryEventMetadataChange {
+		return "", false
+	}
+	return fmt.Sprintf("📝 %s: %s", item.Repo, item.Title), true
+}
+
+// Render lists what changed, e.g. "+2 metadata changes: archived: false → true, visibility: public → private"
+func (metadataSource) Render(change EventChange, _ *EventState) string {
+	noun := "metadata changes"
+	if change.Count == 1 {
+		noun = "metadata change"
+	}
+
+	line := fmt.Sprintf("+%d %s", change.Count, noun)
+	if len(change.Items) > 0 {
+		items := make([]string, len(change.Items))
+		for i, item := range change.Items {
+			items[i] = item.Title
+		}
+		line += ": " + strings.Join(items, ", ")
+	}
+	return line
+}
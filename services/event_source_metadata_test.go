@@ -0,0 +1,40 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+func TestMetadataSource_ReportsChangedSettings(t *testing.T) {
+	previous := services.EventState{Values: map[string]string{
+		"archived": "false", "disabled": "false", "visibility": "public", "default_branch": "master",
+		"license": "MIT", "has_issues": "true", "description": "A CLI", "topics": "cli,go",
+	}}
+	stats := &services.RepoStats{Owner: "owner", Name: "repo", Metadata: &services.RepoMetadata{
+		Archived: true, Visibility: "public", DefaultBranch: "main", License: "MIT",
+		HasIssues: true, HasDiscussions: true, Description: "A CLI", Topics: []string{"go", "cli"},
+	}}
+	state := fetchEventState(t, "metadata", nil, stats, previous)
+
+	// has_discussions has no stored value yet, so it is recorded without being reported
+	want := "+2 metadata changes: archived: false → true, default_branch: master → main"
+	if got := renderEventState("metadata", previous, state); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if len(state.New) != 2 || state.New[0].Type != services.HistoryEventMetadataChange {
+		t.Errorf("Expected two metadata change events, got %+v", state.New)
+	}
+	if state.Count != 2 || state.Values["default_branch"] != "main" || state.Values["has_discussions"] != "true" {
+		t.Errorf("Expected the new settings to be recorded, got %+v", state)
+	}
+}
+
+func TestMetadataSource_NeedsMetadata(t *testing.T) {
+	source, _ := services.LookupEventSource("metadata")
+	_, err := source.Fetch(t.Context(), services.FetchRequest{Repo: "owner/repo", Stats: &services.RepoStats{Owner: "owner", Name: "repo"}})
+	if !errors.Is(err, services.ErrNoAPIClient) {
+		t.Errorf("Expected stats without metadata to be unavailable, got %v", err)
+	}
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Item types reported by the releases source
+const (
+	HistoryEventRelease = "release"
+	HistoryEventTag     = "tag"
+)
+
+// Keys of the HistoryEvent.Values of release and tag items
+const (
+	releaseItemTag        = "tag"
+	releaseItemPrerelease = "prerelease"
+)
+
+// Keys of the release source's EventState.Values
+const (
+	releaseLatestTag       = "latest_tag"
+	releaseLatestURL       = "latest_url"
+	releaseLatestPublished = "latest_published_at"
+	releaseTags            = "tags"
+	releaseDrafts          = "drafts"
+)
+
+// maxKnownTags bounds how many tag names the releases source remembers
+const maxKnownTags = 200
+
+// releaseSource watches published releases and tags pushed without a release. Its cursor is
+// the highest release ID seen, since release IDs only grow, and its count is the number of
+// releases reported since the baseline was taken.
+type releaseSource struct{}
+
+func init() {
+	RegisterEventSource(releaseSource{}, false)
+}
+
+func (releaseSource) Name() string  { return "releases" }
+func (releaseSource) Title() string { return "Releases" }
+func (releaseSource) Emoji() string { return "🚀" }
+
+func (releaseSource) URL(repo string) string {
+	return "https://github.com/" + repo + "/releases"
+}
+
+func (releaseSource) Fetch(ctx context.Context, req FetchRequest) (EventState, error) {
+	if req.Client == nil {
+		return EventState{}, ErrNoAPIClient
+	}
+
+	releases, err := req.Client.GetReleases(ctx, req.Owner, req.Name)
+	if err != nil {
+		return EventState{}, err
+	}
+	tags, err := req.Client.GetTags(ctx, req.Owner, req.Name)
+	if err != nil {
+		return EventState{}, err
+	}
+
+	state := EventState{Count: req.Previous.Count, Cursor: req.Previous.Cursor, Values: map[string]string{}}
+
+	// The first fetch for a repository is the baseline, so existing releases and tags are not reported
+	previousTags, hasPrevious := req.Previous.Values[releaseTags]
+	previousDrafts := splitValues(req.Previous.Values[releaseDrafts])
+
+	// Releases are listed newest first
+	lastSeen, _ := strconv.ParseInt(req.Previous.Cursor, 10, 64)
+	releaseTagNames := make(map[string]bool, len(releases))
+	var drafts []string
+	var latest *ReleaseAPIData
+	for i := len(releases) - 1; i >= 0; i-- {
+		release := releases[i]
+		releaseTagNames[release.TagName] = true
+		id := strconv.FormatInt(release.ID, 10)
+
+		// A draft keeps its ID when it is published, so drafts are remembered and reported then
+		if release.Draft {
+			drafts = append(drafts, id)
+		} else if hasPrevious && (release.ID > lastSeen || slices.Contains(previousDrafts, id)) {
+			state.New = append(state.New, NewReleaseEvent(req.Repo, release))
+			state.Count++
+		}
+		if cursor, _ := strconv.ParseInt(state.Cursor, 10, 64); release.ID > cursor {
+			state.Cursor = id
+		}
+		if !release.Draft && !release.Prerelease && (latest == nil || release.PublishedAt.After(latest.PublishedAt)) {
+			latest = &releases[i]
+		}
+	}
+
+	if latest != nil {
+		state.Values[releaseLatestTag] = latest.TagName
+		state.Values[releaseLatestURL] = latest.HTMLURL
+		state.Values[releaseLatestPublished] = latest.PublishedAt.Format(time.RFC3339)
+	}
+	if len(drafts) > 0 {
+		state.Values[releaseDrafts] = strings.Join(drafts, "\n")
+	}
+
+	// Tags are compared by name against the tags seen before, which are kept beyond the
+	// listing so that older tags moving in and out of it are not reported again
+	seenTags := splitValues(previousTags)
+	var names []string
+	now := time.Now()
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+		if hasPrevious && !slices.Contains(seenTags, tag.Name) && !releaseTagNames[tag.Name] {
+			state.New = append(state.New, NewTagEvent(req.Repo, tag, now))
+		}
+	}
+	for _, name := range seenTags {
+		if len(names) >= maxKnownTags {
+			break
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	state.Values[releaseTags] = strings.Join(names, "\n")
+
+	return state, nil
+}
+
+// splitValues splits a newline-separated EventState value
+func splitValues(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, "\n")
+}
+
+// ApplyReleaseDelivery updates a releases state with a release published through a webhook,
+// so that it is reported once and the next poll does not report it again
+func ApplyReleaseDelivery(previous EventState, repo string, release ReleaseAPIData) EventState {
+	state := EventState{Count: previous.Count, Cursor: previous.Cursor, Values: maps.Clone(previous.Values)}
+	if state.Values == nil {
+		state.Values = map[string]string{}
+	}
+
+	id := strconv.FormatInt(release.ID, 10)
+	lastSeen, _ := strconv.ParseInt(previous.Cursor, 10, 64)
+	drafts := splitValues(previous.Values[releaseDrafts])
+	if release.ID > lastSeen || slices.Contains(drafts, id) {
+		state.New = append(state.New, NewReleaseEvent(repo, release))
+		state.Count++
+	}
+	if release.ID > lastSeen {
+		state.Cursor = id
+	}
+	if i := slices.Index(drafts, id); i >= 0 {
+		drafts = slices.Delete(drafts, i, i+1)
+		if len(drafts) > 0 {
+			state.Values[releaseDrafts] = strings.Join(drafts, "\n")
+		} else {
+			delete(state.Values, releaseDrafts)
+		}
+	}
+
+	_, _, latestPublished, hasLatest := latestRelease(previous)
+	if !release.Draft && !release.Prerelease && (!hasLatest || release.PublishedAt.After(latestPublished)) {
+		state.Values[releaseLatestTag] = release.TagName
+		state.Values[releaseLatestURL] = release.HTMLURL
+		state.Values[releaseLatestPublished] = release.PublishedAt.Format(time.RFC3339)
+	}
+
+	return state
+}
+
+// NewReleaseEvent creates an event for a published release
+func NewReleaseEvent(repo string, release ReleaseAPIData) HistoryEvent {
+	title := release.Name
+	if title == "" {
+		title = release.TagName
+	}
+
+	values := map[string]string{releaseItemTag: release.TagName}
+	if release.Prerelease {
+		values[releaseItemPrerelease] = "true"
+	}
+
+	return HistoryEvent{
+		ID:     fmt.Sprintf("%s/%s/%d", repo, HistoryEventRelease, release.ID),
+		Repo:   repo,
+		Type:   HistoryEventRelease,
+		Time:   release.PublishedAt,
+		Title:  title,
+		URL:    release.HTMLURL,
+		Author: release.Author.Login,
+		Values: values,
+	}
+}
+
+// NewTagEvent creates an event for a tag pushed without a release
+func NewTagEvent(repo string, tag TagAPIData, at time.Time) HistoryEvent {
+	return HistoryEvent{
+		ID:     fmt.Sprintf("%s/%s/%s", repo, HistoryEventTag, tag.Name),
+		Repo:   repo,
+		Type:   HistoryEventTag,
+		Time:   at,
+		Title:  tag.Name,
+		URL:    fmt.Sprintf("https://github.com/%s/releases/tag/%s", repo, url.PathEscape(tag.Name)),
+		Values: map[string]string{releaseItemTag: tag.Name},
+	}
+}
+
+// Render lists the new releases, e.g. "+2 releases: v1.2.0 by alice, v1.3.0-rc.1 (pre-release) by bob"
+func (releaseSource) Render(change EventChange, _ *EventState) string {
+	noun := "releases"
+	if change.Count == 1 {
+		noun = "release"
+	}
+	if len(change.Items) == 0 {
+		return fmt.Sprintf("+%d %s", change.Count, noun)
+	}
+
+	names := make([]string, len(change.Items))
+	for i, item := range change.Items {
+		names[i] = describeRelease(item)
+	}
+	return fmt.Sprintf("+%d %s: %s", change.Count, noun, strings.Join(names, ", "))
+}
+
+func describeRelease(event HistoryEvent) string {
+	name := event.Values[releaseItemTag]
+	if name == "" {
+		name = event.Title
+	}
+
+	switch {
+	case event.Type == HistoryEventTag:
+		name += " (tag only)"
+	case event.Values[releaseItemPrerelease] == "true":
+		name += " (pre-release)"
+	}
+
+	if event.Author != "" {
+		name += " by " + event.Author
+	}
+	return name
+}
+
+func (releaseSource) DescribeItem(item HistoryEvent) (string, bool) {
+	switch item.Type {
+	case HistoryEventRelease:
+		title := fmt.Sprintf("🚀 %s released %s", item.Repo, item.Title)
+		if item.Values[releaseItemPrerelease] == "true" {
+			title += " (pre-release)"
+		}
+		return title, true
+	case HistoryEventTag:
+		return fmt.Sprintf("🏷️ %s tagged %s", item.Repo, item.Title), true
+	}
+	return "", false
+}
+
+func (releaseSource) DashboardLine(state EventState) (string, string, bool) {
+	tag, _, publishedAt, ok := latestRelease(state)
+	if !ok {
+		return "Latest Release", "none", true
+	}
+	return "Latest Release", fmt.Sprintf("%s (%s)", tag, formatAge(time.Since(publishedAt))), true
+}
+
+// latestRelease returns the newest published release recorded in a releases state, ignoring pre-releases as GitHub does
+func latestRelease(state EventState) (tag, url string, publishedAt time.Time, ok bool) {
+	tag = state.Values[releaseLatestTag]
+	if tag == "" {
+		return "", "", time.Time{}, false
+	}
+
+	publishedAt, _ = time.Parse(time.RFC3339, state.Values[releaseLatestPublished])
+	return tag, state.Values[releaseLatestURL], publishedAt, true
+}
@@ -0,0 +1,156 @@
+package services_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReleaseSource_ReportsReleasesAndTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	now := time.Now()
+	releases := []services.ReleaseAPIData{
+		{ID: 12, TagName: "v1.3.0-rc.1", Prerelease: true, Author: services.UserAPIData{Login: "bob"}, PublishedAt: now},
+		{ID: 11, TagName: "v1.2.0", Author: services.UserAPIData{Login: "alice"}, PublishedAt: now.Add(-time.Hour)},
+		{ID: 10, TagName: "v1.1.0", PublishedAt: now.Add(-30 * 24 * time.Hour)},
+	}
+	tags := []services.TagAPIData{{Name: "v1.3.0-rc.1"}, {Name: "v1.2.0"}, {Name: "v1.1.1"}, {Name: "v1.1.0"}}
+	mockClient.EXPECT().GetReleases(gomock.Any(), "owner", "repo").Return(releases, nil)
+	mockClient.EXPECT().GetTags(gomock.Any(), "owner", "repo").Return(tags, nil)
+
+	previous := services.EventState{Count: 1, Cursor: "10", Values: map[string]string{"tags": "v1.1.0"}}
+	state := fetchEventState(t, "releases", mockClient, nil, previous)
+
+	want := "+3 releases: v1.2.0 by alice, v1.3.0-rc.1 (pre-release) by bob, v1.1.1 (tag only)"
+	if got := renderEventState("releases", previous, state); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if len(state.New) != 3 || state.New[0].Type != services.HistoryEventRelease || state.New[2].Type != services.HistoryEventTag {
+		t.Errorf("Expected two releases and a tag, got %+v", state.New)
+	}
+	if dashboardSummary("releases", state) != "v1.2.0 (today)" || state.Cursor != "12" || state.Count != 3 {
+		t.Errorf("Expected cursor 12, two more releases and latest release v1.2.0, got %+v", state)
+	}
+}
+
+func TestReleaseSource_ReportsPublishedDrafts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	now := time.Now()
+	draft := services.ReleaseAPIData{ID: 11, TagName: "v1.2.0", Draft: true, CreatedAt: now.Add(-time.Hour)}
+	published := draft
+	published.Draft = false
+	published.PublishedAt = now
+
+	gomock.InOrder(
+		mockClient.EXPECT().GetReleases(gomock.Any(), "owner", "repo").Return([]services.ReleaseAPIData{{ID: 10, TagName: "v1.1.0"}}, nil),
+		mockClient.EXPECT().GetReleases(gomock.Any(), "owner", "repo").Return([]services.ReleaseAPIData{draft, {ID: 10, TagName: "v1.1.0"}}, nil),
+		mockClient.EXPECT().GetReleases(gomock.Any(), "owner", "repo").Return([]services.ReleaseAPIData{published, {ID: 10, TagName: "v1.1.0"}}, nil),
+		mockClient.EXPECT().GetReleases(gomock.Any(), "owner", "repo").Return([]services.ReleaseAPIData{published, {ID: 10, TagName: "v1.1.0"}}, nil),
+	)
+	mockClient.EXPECT().GetTags(gomock.Any(), "owner", "repo").Return(nil, nil).Times(4)
+
+	state := fetchEventState(t, "releases", mockClient, nil, services.EventState{})
+	if len(state.New) != 0 {
+		t.Fatalf("Expected the first fetch to be a baseline, got %+v", state.New)
+	}
+
+	state = fetchEventState(t, "releases", mockClient, nil, state)
+	if len(state.New) != 0 {
+		t.Fatalf("Expected the draft not to be reported, got %+v", state.New)
+	}
+
+	state = fetchEventState(t, "releases", mockClient, nil, state)
+	if len(state.New) != 1 || state.New[0].ID != "owner/repo/release/11" || !state.New[0].Time.Equal(now) {
+		t.Fatalf("Expected the publication to be reported, got %+v", state.New)
+	}
+	if state.Count != 1 {
+		t.Errorf("Expected one release reported since the baseline, got %d", state.Count)
+	}
+
+	state = fetchEventState(t, "releases", mockClient, nil, state)
+	if len(state.New) != 0 {
+		t.Errorf("Expected the publication to be reported once, got %+v", state.New)
+	}
+}
+
+func TestApplyReleaseDelivery_ReportsPublishedDrafts(t *testing.T) {
+	previous := services.EventState{Count: 2, Cursor: "11", Values: map[string]string{"tags": "", "drafts": "11"}}
+	release := services.ReleaseAPIData{ID: 11, TagName: "v1.2.0", PublishedAt: time.Now()}
+
+	state := services.ApplyReleaseDelivery(previous, "owner/repo", release)
+	if len(state.New) != 1 || state.New[0].ID != "owner/repo/release/11" {
+		t.Fatalf("Expected the publication to be reported, got %+v", state.New)
+	}
+	if state.Count != 3 || state.Values["drafts"] != "" {
+		t.Errorf("Expected the release to be counted and the draft forgotten, got %+v", state)
+	}
+
+	if state = services.ApplyReleaseDelivery(state, "owner/repo", release); len(state.New) != 0 {
+		t.Errorf("Expected a repeated delivery not to be reported, got %+v", state.New)
+	}
+}
+
+func TestReleaseSource_RemembersTagsBeyondTheListing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	tags := func(names ...string) []services.TagAPIData {
+		list := make([]services.TagAPIData, len(names))
+		for i, name := range names {
+			list[i].Name = name
+		}
+		return list
+	}
+
+	mockClient.EXPECT().GetReleases(gomock.Any(), "owner", "repo").Return(nil, nil).Times(3)
+	gomock.InOrder(
+		mockClient.EXPECT().GetTags(gomock.Any(), "owner", "repo").Return(tags("v3", "v2"), nil),
+		mockClient.EXPECT().GetTags(gomock.Any(), "owner", "repo").Return(tags("v4", "v3"), nil),
+		// v2 comes back into the listing, e.g. after a tag was deleted
+		mockClient.EXPECT().GetTags(gomock.Any(), "owner", "repo").Return(tags("v4", "v2"), nil),
+	)
+
+	state := fetchEventState(t, "releases", mockClient, nil, services.EventState{})
+
+	state = fetchEventState(t, "releases", mockClient, nil, state)
+	if len(state.New) != 1 || state.New[0].Title != "v4" {
+		t.Fatalf("Expected v4 to be reported, got %+v", state.New)
+	}
+
+	state = fetchEventState(t, "releases", mockClient, nil, state)
+	if len(state.New) != 0 {
+		t.Errorf("Expected a known tag not to be reported again, got %+v", state.New)
+	}
+	if want := "v4\nv2\nv3"; state.Values["tags"] != want {
+		t.Errorf("Expected known tags %q, got %q", want, state.Values["tags"])
+	}
+}
+
+func TestReleaseSource_BoundsKnownTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	known := make([]string, 300)
+	for i := range known {
+		known[i] = fmt.Sprintf("v0.%d", i)
+	}
+	mockClient.EXPECT().GetReleases(gomock.Any(), "owner", "repo").Return(nil, nil)
+	mockClient.EXPECT().GetTags(gomock.Any(), "owner", "repo").Return([]services.TagAPIData{{Name: "v1.0"}}, nil)
+
+	state := fetchEventState(t, "releases", mockClient, nil, services.EventState{Values: map[string]string{"tags": strings.Join(known, "\n")}})
+	if len(state.New) != 1 {
+		t.Errorf("Expected v1.0 to be reported, got %+v", state.New)
+	}
+	if got := len(strings.Split(state.Values["tags"], "\n")); got != 200 {
+		t.Errorf("Expected 200 known tags, got %d", got)
+	}
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Alert kinds watched by the security source, in display order
+const (
+	SecurityDependabot     = "dependabot"
+	SecurityCodeScanning   = "code_scanning"
+	SecuritySecretScanning = "secret_scanning"
+)
+
+// securitySeverities are the alert severities counted, most severe first. Secret scanning
+// alerts have no severity and are counted as "secret".
+var securitySeverities = []string{"critical", "high", "medium", "low"}
+
+// HistoryEventSecurityAlert is the item type reported by the security source
+const HistoryEventSecurityAlert = "security_alert"
+
+// securityItemSeverity is the HistoryEvent.Values key holding an alert's severity
+const securityItemSeverity = "severity"
+
+// securityUnavailable marks an alert kind the token cannot read
+const securityUnavailable = "n/a"
+
+var securityKindTitles = map[string]string{
+	SecurityDependabot:     "Dependabot",
+	SecurityCodeScanning:   "code scanning",
+	SecuritySecretScanning: "secret scanning",
+}
+
+// securityAlertCounts are the open alerts of one kind for a repository
+type securityAlertCounts struct {
+	Kind      string
+	Available bool
+	Total     int
+
+	// BySeverity counts alerts per severity, or per "secret" for secret scanning
+	BySeverity map[string]int
+}
+
+// securityAlert is an open alert of any kind, reduced to what the source reports
+type securityAlert struct {
+	kind      string
+	number    int
+	severity  string
+	title     string
+	url       string
+	createdAt time.Time
+}
+
+// securitySource watches open Dependabot, code scanning and secret scanning alerts. Alert
+// kinds the token cannot read are recorded as unavailable rather than failing the source.
+// Its cursor is the time of the previous fetch; critical and high alerts opened after it,
+// and every new secret scanning alert, are reported.
+type securitySource struct{}
+
+func init() {
+	RegisterEventSource(securitySource{}, false)
+}
+
+func (securitySource) Name() string  { return "security" }
+func (securitySource) Title() string { return "Security Alerts" }
+func (securitySource) Emoji() string { return "🛡️" }
+
+func (securitySource) URL(repo string) string {
+	return "https://github.com/" + repo + "/security"
+}
+
+func (securitySource) Fetch(ctx context.Context, req FetchRequest) (EventState, error) {
+	if req.Client == nil {
+		return EventState{}, ErrNoAPIClient
+	}
+
+	// Taken before the requests so alerts opened while they run are seen next time
+	now := time.Now()
+	state := EventState{Cursor: now.Format(time.RFC3339), Values: map[string]string{}}
+
+	fetchers := []struct {
+		kind  string
+		fetch func() ([]securityAlert, error)
+	}{
+		{SecurityDependabot, func() ([]securityAlert, error) { return fetchDependabotAlerts(ctx, req) }},
+		{SecurityCodeScanning, func() ([]securityAlert, error) { return fetchCodeScanningAlerts(ctx, req) }},
+		{SecuritySecretScanning, func() ([]securityAlert, error) { return fetchSecretScanningAlerts(ctx, req) }},
+	}
+
+	// The first fetch for a repository is the baseline, so existing alerts are not reported
+	since, err := time.Parse(time.RFC3339, req.Previous.Cursor)
+	baseline := err != nil
+
+	for _, fetcher := range fetchers {
+		alerts, err := fetcher.fetch()
+		if isPermissionError(err) {
+			state.Values[fetcher.kind] = securityUnavailable
+			continue
+		}
+		if err != nil {
+			return EventState{}, err
+		}
+
+		state.Values[fetcher.kind] = strconv.Itoa(len(alerts))
+		state.Count += len(alerts)
+		counts := map[string]int{}
+		for _, alert := range alerts {
+			counts[alert.severity]++
+			if !baseline && alert.createdAt.After(since) && urgentSecurityAlert(alert) {
+				state.New = append(state.New, newSecurityEvent(req.Repo, alert))
+			}
+		}
+		for severity, count := range counts {
+			state.Values[fetcher.kind+"/"+severity] = strconv.Itoa(count)
+		}
+	}
+
+	return state, nil
+}
+
+// isPermissionError reports whether err means the token may not read the alerts, or the
+// feature is disabled for the repository, which GitHub reports as 403 or 404. A 403 may
+// also be a rate limit, which the client classifies and which must not hide the alerts.
+func isPermissionError(err error) bool {
+	var ghErr *GitHubError
+	if !errors.As(err, &ghErr) || ghErr.Type == ErrorTypeRateLimit {
+		return false
+	}
+	return ghErr.StatusCode == http.StatusNotFound || ghErr.StatusCode == http.StatusForbidden
+}
+
+func urgentSecurityAlert(alert securityAlert) bool {
+	return alert.severity == "critical" || alert.severity == "high" || alert.kind == SecuritySecretScanning
+}
+
+func fetchDependabotAlerts(ctx context.Context, req FetchRequest) ([]securityAlert, error) {
+	alerts, err := req.Client.GetDependabotAlerts(ctx, req.Owner, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]securityAlert, len(alerts))
+	for i, alert := range alerts {
+		title := alert.SecurityAdvisory.Summary
+		if pkg := alert.Dependency.Package.Name; pkg != "" {
+			title = pkg + ": " + title
+		}
+		result[i] = securityAlert{
+			kind:      SecurityDependabot,
+			number:    alert.Number,
+			severity:  normalizeSeverity(alert.SecurityAdvisory.Severity),
+			title:     title,
+			url:       alert.HTMLURL,
+			createdAt: alert.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+func fetchCodeScanningAlerts(ctx context.Context, req FetchRequest) ([]securityAlert, error) {
+	alerts, err := req.Client.GetCodeScanningAlerts(ctx, req.Owner, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]securityAlert, len(alerts))
+	for i, alert := range alerts {
+		// Rules without a security severity are quality findings; rank them by their own severity
+		severity := alert.Rule.SecuritySeverityLevel
+		if severity == "" {
+			severity = map[string]string{"error": "high", "warning": "medium", "note": "low"}[alert.Rule.Severity]
+		}
+		result[i] = securityAlert{
+			kind:      SecurityCodeScanning,
+			number:    alert.Number,
+			severity:  normalizeSeverity(severity),
+			title:     alert.Rule.Description,
+			url:       alert.HTMLURL,
+			createdAt: alert.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+func fetchSecretScanningAlerts(ctx context.Context, req FetchRequest) ([]securityAlert, error) {
+	alerts, err := req.Client.GetSecretScanningAlerts(ctx, req.Owner, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]securityAlert, len(alerts))
+	for i, alert := range alerts {
+		result[i] = securityAlert{
+			kind:      SecuritySecretScanning,
+			number:    alert.Number,
+			severity:  "secret",
+			title:     alert.SecretTypeDisplayName,
+			url:       alert.HTMLURL,
+			createdAt: alert.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+// normalizeSeverity maps GitHub's severities onto securitySeverities; Dependabot calls medium "moderate"
+func normalizeSeverity(severity string) string {
+	severity = strings.ToLower(severity)
+	switch severity {
+	case "moderate":
+		return "medium"
+	case "critical", "high", "medium", "low":
+		return severity
+	default:
+		return "low"
+	}
+}
+
+func newSecurityEvent(repo string, alert securityAlert) HistoryEvent {
+	return HistoryEvent{
+		ID:     fmt.Sprintf("%s/%s/%s/%d", repo, HistoryEventSecurityAlert, alert.kind, alert.number),
+		Repo:   repo,
+		Type:   HistoryEventSecurityAlert,
+		Time:   alert.createdAt,
+		Number: alert.number,
+		Title:  securityKindTitles[alert.kind] + " " + alert.title,
+		URL:    alert.url,
+		Values: map[string]string{securityItemSeverity: alert.severity},
+	}
+}
+
+// describeSecurityAlert prefixes an alert with its severity, e.g. "critical Dependabot lodash: Prototype pollution"
+func describeSecurityAlert(item HistoryEvent) string {
+	if severity := item.Values[securityItemSeverity]; severity != "secret" {
+		return severity + " " + item.Title
+	}
+	return item.Title
+}
+
+func (securitySource) DescribeItem(item HistoryEvent) (string, bool) {
+	if item.Type != HistoryEventSecurityAlert {
+		return "", false
+	}
+	return fmt.Sprintf("🛡️ %s: %s", item.Repo, describeSecurityAlert(item)), true
+}
+
+// Render lists new urgent alerts, e.g. "+1 security alert: critical Dependabot lodash: Prototype pollution (7 open)"
+func (securitySource) Render(change EventChange, current *EventState) string {
+	noun := "security alerts"
+	if change.Count == 1 {
+		noun = "security alert"
+	}
+
+	line := fmt.Sprintf("+%d %s", change.Count, noun)
+	if len(change.Items) > 0 {
+		items := make([]string, len(change.Items))
+		for i, item := range change.Items {
+			items[i] = describeSecurityAlert(item)
+		}
+		line += ": " + strings.Join(items, ", ")
+	}
+
+	if current != nil {
+		line += fmt.Sprintf(" (%d open)", current.Count)
+	}
+	return line
+}
+
+// DashboardLine summarizes open alerts per kind, e.g. "Dependabot 1 critical, 2 high · code scanning n/a · secret scanning 0"
+func (securitySource) DashboardLine(state EventState) (string, string, bool) {
+	var parts []string
+	for _, kind := range securityAlerts(state) {
+		title := securityKindTitles[kind.Kind]
+		if !kind.Available {
+			parts = append(parts, title+" n/a")
+			continue
+		}
+
+		var counts []string
+		for _, severity := range securitySeverities {
+			if n := kind.BySeverity[severity]; n > 0 {
+				counts = append(counts, fmt.Sprintf("%d %s", n, severity))
+			}
+		}
+		if len(counts) == 0 {
+			counts = append(counts, strconv.Itoa(kind.Total))
+		}
+		parts = append(parts, title+" "+strings.Join(counts, ", "))
+	}
+	return "Security", strings.Join(parts, " · "), true
+}
+
+// securityAlerts returns the open alert counts recorded in a security state, one entry per kind
+func securityAlerts(state EventState) []securityAlertCounts {
+	var counts []securityAlertCounts
+	for _, kind := range []string{SecurityDependabot, SecurityCodeScanning, SecuritySecretScanning} {
+		value, ok := state.Values[kind]
+		if !ok {
+			continue
+		}
+
+		entry := securityAlertCounts{Kind: kind, Available: value != securityUnavailable, BySeverity: map[string]int{}}
+		entry.Total, _ = strconv.Atoi(value)
+		for _, severity := range append(securitySeverities, "secret") {
+			if n, err := strconv.Atoi(state.Values[kind+"/"+severity]); err == nil {
+				entry.BySeverity[severity] = n
+			}
+		}
+		counts = append(counts, entry)
+	}
+	return counts
+}
@@ -0,0 +1,83 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSecuritySource_OnlyPermissionErrorsAreUnavailable(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		unavailable bool
+	}{
+		{"forbidden", services.NewAPIError("access forbidden", 403, "owner/repo", errors.New("HTTP 403: Resource not accessible by integration")), true},
+		{"disabled", services.NewAPIError("resource not found", 404, "owner/repo", nil), true},
+		{"rate limit", &services.GitHubError{Type: services.ErrorTypeRateLimit, StatusCode: 403, Message: "rate limit exceeded"}, false},
+		{"bad credentials", services.NewAPIError("authentication failed", 401, "owner/repo", nil), false},
+	}
+
+	source, _ := services.LookupEventSource("security")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+			mockClient.EXPECT().GetDependabotAlerts(gomock.Any(), "owner", "repo").Return(nil, tt.err)
+			mockClient.EXPECT().GetCodeScanningAlerts(gomock.Any(), "owner", "repo").Return(nil, nil).AnyTimes()
+			mockClient.EXPECT().GetSecretScanningAlerts(gomock.Any(), "owner", "repo").Return(nil, nil).AnyTimes()
+
+			state, err := source.Fetch(context.Background(), services.FetchRequest{
+				Repo: "owner/repo", Owner: "owner", Name: "repo", Client: mockClient,
+			})
+			if tt.unavailable {
+				if err != nil || state.Values[services.SecurityDependabot] != "n/a" {
+					t.Errorf("Expected Dependabot alerts to be unavailable, got %+v, %v", state, err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected the error to fail the fetch, got %+v, %v", state, err)
+			}
+		})
+	}
+}
+
+func TestSecuritySource_ReportsUrgentAlerts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	since := time.Now().Add(-time.Hour)
+	alert := func(number int, severity string, createdAt time.Time) services.DependabotAlertAPIData {
+		a := services.DependabotAlertAPIData{Number: number, CreatedAt: createdAt}
+		a.SecurityAdvisory.Severity = severity
+		a.SecurityAdvisory.Summary = "Prototype pollution"
+		a.Dependency.Package.Name = "lodash"
+		return a
+	}
+	mockClient.EXPECT().GetDependabotAlerts(gomock.Any(), "owner", "repo").Return([]services.DependabotAlertAPIData{
+		alert(3, "critical", since.Add(time.Minute)),
+		alert(2, "moderate", since.Add(time.Minute)),
+		alert(1, "high", since.Add(-24*time.Hour)),
+	}, nil)
+	mockClient.EXPECT().GetCodeScanningAlerts(gomock.Any(), "owner", "repo").
+		Return(nil, services.NewAPIError("access forbidden", 403, "owner/repo", nil))
+	mockClient.EXPECT().GetSecretScanningAlerts(gomock.Any(), "owner", "repo").
+		Return(nil, services.NewAPIError("resource not found", 404, "owner/repo", nil))
+
+	previous := services.EventState{Count: 1, Cursor: since.Format(time.RFC3339)}
+	state := fetchEventState(t, "security", mockClient, nil, previous)
+
+	want := "+1 security alert: critical Dependabot lodash: Prototype pollution (3 open)"
+	if got := renderEventState("security", previous, state); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if state.Values[services.SecurityCodeScanning] != "n/a" || state.Values[services.SecuritySecretScanning] != "n/a" {
+		t.Errorf("Expected code and secret scanning to be unavailable, got %+v", state.Values)
+	}
+}
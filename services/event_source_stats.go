@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"fmt"
+)
+
+// statCountSource watches a counter included in the repository stats, so it needs no extra API calls
+type statCountSource struct {
+	name       string
+	title      string
+	emoji      string
+	noun       string
+	totalLabel string
+	path       string
+	count      func(stats *RepoStats) int
+
+	// apiOnly marks counters missing from stats built without the API, such as from webhooks
+	apiOnly bool
+}
+
+func init() {
+	RegisterEventSource(statCountSource{
+		name: "stars", title: "Stars", emoji: "⭐", noun: "stars", totalLabel: "total", path: "/stargazers",
+		count: func(stats *RepoStats) int { return stats.Stars },
+	}, true)
+	RegisterEventSource(statCountSource{
+		name: "issues", title: "Issues", emoji: "🐛", noun: "issues", totalLabel: "open", path: "/issues",
+		count: func(stats *RepoStats) int { return stats.Issues },
+	}, true)
+	RegisterEventSource(statCountSource{
+		name: "pull_requests", title: "Pull Requests", emoji: "🔀", noun: "pull requests", totalLabel: "open", path: "/pulls",
+		count: func(stats *RepoStats) int { return stats.PullRequests },
+	}, true)
+	RegisterEventSource(statCountSource{
+		name: "forks", title: "Forks", emoji: "🍴", noun: "forks", totalLabel: "total", path: "/forks",
+		count: func(stats *RepoStats) int { return stats.Forks },
+	}, true)
+	RegisterEventSource(statCountSource{
+		name: "watchers", title: "Watchers", emoji: "👁️", noun: "watchers", totalLabel: "total", path: "/watchers",
+		count: func(stats *RepoStats) int { return stats.Watchers }, apiOnly: true,
+	}, false)
+}
+
+func (s statCountSource) Name() string  { return s.name }
+func (s statCountSource) Title() string { return s.title }
+func (s statCountSource) Emoji() string { return s.emoji }
+
+func (s statCountSource) URL(repo string) string {
+	return "https://github.com/" + repo + s.path
+}
+
+func (s statCountSource) Fetch(_ context.Context, req FetchRequest) (EventState, error) {
+	if s.apiOnly && req.Client == nil {
+		return EventState{}, ErrNoAPIClient
+	}
+	return EventState{Count: s.count(req.Stats)}, nil
+}
+
+// Diff only reports increases; closed issues or removed stars are not news
+func (s statCountSource) Diff(previous, current EventState) EventChange {
+	if current.Count <= previous.Count {
+		return EventChange{}
+	}
+	return EventChange{Count: current.Count - previous.Count}
+}
+
+func (s statCountSource) Render(change EventChange, current *EventState) string {
+	if current == nil {
+		return fmt.Sprintf("+%d %s", change.Count, s.noun)
+	}
+	return fmt.Sprintf("+%d %s (%d %s)", change.Count, s.noun, current.Count, s.totalLabel)
+}
@@ -0,0 +1,42 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+// fetchEventState fetches an event source's state for owner/repo, failing the test on error
+func fetchEventState(t *testing.T, event string, client services.GitHubAPIClient, stats *services.RepoStats, previous services.EventState) services.EventState {
+	t.Helper()
+
+	source, ok := services.LookupEventSource(event)
+	if !ok {
+		t.Fatalf("Expected event source %q to be registered", event)
+	}
+	if stats == nil {
+		stats = &services.RepoStats{Owner: "owner", Name: "repo"}
+	}
+
+	state, err := source.Fetch(context.Background(), services.FetchRequest{
+		Repo: "owner/repo", Owner: "owner", Name: "repo", Stats: stats, Previous: previous, Client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return state
+}
+
+// renderEventState renders what a fetched state reports, as status prints it
+func renderEventState(event string, previous, current services.EventState) string {
+	source, _ := services.LookupEventSource(event)
+	return source.Render(services.DiffEventState(source, previous, current), &current)
+}
+
+// dashboardSummary returns the summary a fetched state shows on the dashboard, empty when it shows none
+func dashboardSummary(event string, state services.EventState) string {
+	source, _ := services.LookupEventSource(event)
+	_, summary, _ := source.(services.DashboardSummarizer).DashboardLine(state)
+	return summary
+}
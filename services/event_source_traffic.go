@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Keys of the traffic source's EventState.Values, covering the 14 days GitHub reports
+const (
+	trafficViews    = "views"
+	trafficVisitors = "visitors"
+	trafficClones   = "clones"
+	trafficCloners  = "cloners"
+	trafficAccess   = "access"
+)
+
+// trafficTotals is a repository's traffic over the 14 days GitHub reports
+type trafficTotals struct {
+	Available bool
+	Views     int
+	Visitors  int
+	Clones    int
+	Cloners   int
+}
+
+// trafficData is what a traffic fetch hands to MergeHistory
+type trafficData struct {
+	days      []TrafficDay
+	referrers []TrafficEntry
+	paths     []TrafficEntry
+}
+
+// trafficSource archives views, clones, referrers and popular paths, which GitHub only keeps
+// for 14 days. It needs push access; without it the source records traffic as unavailable.
+// Traffic is archived rather than reported, so the source never reports changes.
+type trafficSource struct{}
+
+func init() {
+	RegisterEventSource(trafficSource{}, false)
+}
+
+func (trafficSource) Name() string  { return "traffic" }
+func (trafficSource) Title() string { return "Traffic" }
+func (trafficSource) Emoji() string { return "👀" }
+
+func (trafficSource) URL(repo string) string {
+	return "https://github.com/" + repo + "/graphs/traffic"
+}
+
+func (trafficSource) Fetch(ctx context.Context, req FetchRequest) (EventState, error) {
+	if req.Client == nil {
+		return EventState{}, ErrNoAPIClient
+	}
+
+	traffic, err := req.Client.GetTraffic(ctx, req.Owner, req.Name)
+	if isPermissionError(err) {
+		return EventState{Values: map[string]string{trafficAccess: "n/a"}}, nil
+	}
+	if err != nil {
+		return EventState{}, err
+	}
+
+	days := map[time.Time]*TrafficDay{}
+	day := func(timestamp time.Time) *TrafficDay {
+		date := timestamp.UTC()
+		if days[date] == nil {
+			days[date] = &TrafficDay{Date: date}
+		}
+		return days[date]
+	}
+
+	for _, point := range traffic.Views.Days {
+		d := day(point.Timestamp)
+		d.Views, d.Visitors = point.Count, point.Uniques
+	}
+	for _, point := range traffic.Clones.Days {
+		d := day(point.Timestamp)
+		d.Clones, d.Cloners = point.Count, point.Uniques
+	}
+
+	data := trafficData{}
+	for _, d := range days {
+		data.days = append(data.days, *d)
+	}
+	for _, referrer := range traffic.Referrers {
+		data.referrers = append(data.referrers, TrafficEntry{Name: referrer.Referrer, Count: referrer.Count, Uniques: referrer.Uniques})
+	}
+	for _, path := range traffic.Paths {
+		data.paths = append(data.paths, TrafficEntry{Name: path.Path, Title: path.Title, Count: path.Count, Uniques: path.Uniques})
+	}
+
+	return EventState{
+		Count: traffic.Views.Count,
+		Values: map[string]string{
+			trafficViews:    strconv.Itoa(traffic.Views.Count),
+			trafficVisitors: strconv.Itoa(traffic.Views.Uniques),
+			trafficClones:   strconv.Itoa(traffic.Clones.Count),
+			trafficCloners:  strconv.Itoa(traffic.Clones.Uniques),
+		},
+		Data: data,
+	}, nil
+}
+
+// MergeHistory archives the fetched daily points, referrers and paths
+func (trafficSource) MergeHistory(history *HistoryData, repo string, state EventState, at time.Time) {
+	data, ok := state.Data.(trafficData)
+	if !ok {
+		return
+	}
+	history.MergeTraffic(repo, data.days, data.referrers, data.paths, at)
+}
+
+func (trafficSource) Render(change EventChange, current *EventState) string {
+	if current == nil {
+		return fmt.Sprintf("+%d views", change.Count)
+	}
+	return fmt.Sprintf("+%d views (%d in 14 days)", change.Count, current.Count)
+}
+
+func (trafficSource) DashboardLine(state EventState) (string, string, bool) {
+	traffic, ok := readTraffic(state)
+	if !ok {
+		return "", "", false
+	}
+	if !traffic.Available {
+		return "Traffic (14 days)", "n/a", true
+	}
+	return "Traffic (14 days)", fmt.Sprintf("%d views, %d unique visitors", traffic.Views, traffic.Visitors), true
+}
+
+// readTraffic returns the 14-day totals recorded in a traffic state
+func readTraffic(state EventState) (trafficTotals, bool) {
+	if state.Values == nil {
+		return trafficTotals{}, false
+	}
+	if state.Values[trafficAccess] == "n/a" {
+		return trafficTotals{}, true
+	}
+
+	summary := trafficTotals{Available: true}
+	summary.Views, _ = strconv.Atoi(state.Values[trafficViews])
+	summary.Visitors, _ = strconv.Atoi(state.Values[trafficVisitors])
+	summary.Clones, _ = strconv.Atoi(state.Values[trafficClones])
+	summary.Cloners, _ = strconv.Atoi(state.Values[trafficCloners])
+	return summary, true
+}
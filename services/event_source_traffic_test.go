@@ -0,0 +1,53 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTrafficSource_ArchivesDailyTraffic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	traffic := &services.TrafficAPIData{
+		Views: services.TrafficSeriesAPIData{Count: 150, Uniques: 40, Days: []services.TrafficCountAPIData{
+			{Timestamp: today.Add(-24 * time.Hour), Count: 100, Uniques: 30},
+			{Timestamp: today, Count: 50, Uniques: 20},
+		}},
+		Clones:    services.TrafficSeriesAPIData{Count: 3, Uniques: 2, Days: []services.TrafficCountAPIData{{Timestamp: today, Count: 3, Uniques: 2}}},
+		Referrers: []services.TrafficPopularAPIData{{Referrer: "news.ycombinator.com", Count: 90, Uniques: 25}},
+		Paths:     []services.TrafficPopularAPIData{{Path: "/owner/repo", Title: "owner/repo", Count: 120, Uniques: 35}},
+	}
+	mockClient.EXPECT().GetTraffic(gomock.Any(), "owner", "repo").Return(traffic, nil)
+
+	state := fetchEventState(t, "traffic", mockClient, nil, services.EventState{})
+	if len(state.New) != 0 {
+		t.Errorf("Expected traffic to be archived without being reported, got %+v", state.New)
+	}
+
+	if want := "150 views, 40 unique visitors"; dashboardSummary("traffic", state) != want {
+		t.Errorf("Expected %q, got %q", want, dashboardSummary("traffic", state))
+	}
+
+	source, _ := services.LookupEventSource("traffic")
+	history := &services.HistoryData{Traffic: map[string]services.TrafficHistory{
+		"owner/repo": {Days: []services.TrafficDay{
+			{Date: today.Add(-20 * 24 * time.Hour), Views: 7},
+			{Date: today.Add(-24 * time.Hour), Views: 80},
+		}},
+	}}
+	source.(services.HistoryMerger).MergeHistory(history, "owner/repo", state, time.Now())
+
+	archive := history.Traffic["owner/repo"]
+	if len(archive.Days) != 3 || archive.Days[0].Views != 7 || archive.Days[1].Views != 100 || archive.Days[2].Clones != 3 {
+		t.Errorf("Expected the old day kept, yesterday revised and today added, got %+v", archive.Days)
+	}
+	if len(archive.Referrers) != 1 || archive.Referrers[0].Entries[0].Name != "news.ycombinator.com" {
+		t.Errorf("Expected a referrer snapshot, got %+v", archive.Referrers)
+	}
+}
@@ -3,12 +3,37 @@ package services
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
+	"sync"
+	"time"
 )
 
+// maxStargazerLookups bounds how many new stargazers get their profile fetched per repository
+const maxStargazerLookups = 30
+
+const (
+	// userLookupTimeout bounds each stargazer profile lookup, which get their own deadline
+	// so that slow lookups cannot use up the time given to the rest of the activity fetch
+	userLookupTimeout = 5 * time.Second
+
+	// userLookupWorkers bounds how many stargazer profiles are fetched at once
+	userLookupWorkers = 5
+)
+
+const pinIssueMutation = `mutation($issueId: ID!) {
+  pinIssue(input: {issueId: $issueId}) {
+    issue { number }
+  }
+}`
+
 // GitHubBaseService provides common GitHub operations for both single and concurrent services
 type GitHubBaseService struct {
 	client GitHubAPIClient
+
+	// users caches stargazer profiles, which are looked up again by every digest
+	usersMu sync.Mutex
+	users   map[string]UserAPIData
 }
 
 // NewGitHubBaseService creates a new base GitHub service
@@ -37,48 +62,225 @@ func (g *GitHubBaseService) GetRepoStats(ctx context.Context, owner, repo string
 		Issues:       repoData.OpenIssuesCount,
 		PullRequests: len(prs),
 		Forks:        repoData.ForksCount,
+		Watchers:     repoData.SubscribersCount,
 		UpdatedAt:    repoData.UpdatedAt,
+
+		DefaultBranch: repoData.DefaultBranch,
+		Metadata:      newRepoMetadata(repoData),
 	}, nil
 }
 
-// ParseRepoString parses a repository string in the format "owner/repo"
-func ParseRepoString(repoStr string) (owner, repo string, err error) {
-	parts := strings.Split(repoStr, "/")
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		return "", "", NewValidationError(
-			fmt.Sprintf("invalid repo format: %s (expected owner/repo)", repoStr),
-			repoStr,
-			nil,
-		)
+func newRepoMetadata(repoData *RepoAPIData) *RepoMetadata {
+	license := ""
+	if repoData.License != nil {
+		license = repoData.License.SPDXID
+	}
+
+	return &RepoMetadata{
+		Description:    repoData.Description,
+		Homepage:       repoData.Homepage,
+		Topics:         repoData.Topics,
+		License:        license,
+		DefaultBranch:  repoData.DefaultBranch,
+		Visibility:     repoData.Visibility,
+		Archived:       repoData.Archived,
+		Disabled:       repoData.Disabled,
+		HasIssues:      repoData.HasIssues,
+		HasDiscussions: repoData.HasDiscussions,
 	}
-	return parts[0], parts[1], nil
 }
 
-// CalculateEventSummary compares current stats with previous state to determine changes
-func CalculateEventSummary(repoStr string, current *RepoStats, previous RepoState) EventSummary {
-	summary := EventSummary{
-		Repo: repoStr,
+// GetRepoActivity fetches issues, pull requests, releases and stargazers created since the given time
+func (g *GitHubBaseService) GetRepoActivity(ctx context.Context, owner, repo string, since time.Time) (*RepoActivity, error) {
+	activity := &RepoActivity{Repo: fmt.Sprintf("%s/%s", owner, repo)}
+
+	issues, err := g.client.GetIssuesSince(ctx, owner, repo, since)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		if issue.PullRequest != nil {
+			activity.PullRequests = append(activity.PullRequests, issue)
+		} else {
+			activity.Issues = append(activity.Issues, issue)
+		}
 	}
 
-	if current.Stars > previous.LastStarCount {
-		summary.NewStars = current.Stars - previous.LastStarCount
-		summary.HasChanges = true
+	releases, err := g.client.GetReleases(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, release := range releases {
+		if !release.Draft && !release.PublishedAt.Before(since) {
+			activity.Releases = append(activity.Releases, release)
+		}
 	}
 
-	if current.Issues > previous.LastIssueCount {
-		summary.NewIssues = current.Issues - previous.LastIssueCount
-		summary.HasChanges = true
+	// Issues and releases are still returned when stargazers cannot be fetched
+	stargazers, err := g.client.GetStargazersSince(ctx, owner, repo, since)
+	if err != nil {
+		return activity, err
 	}
+	g.lookupStargazers(ctx, stargazers[:min(len(stargazers), maxStargazerLookups)])
+	activity.Stargazers = stargazers
+
+	return activity, nil
+}
+
+// lookupStargazers fills in stargazer profiles, such as their follower count. Lookups are
+// best effort: a stargazer whose profile cannot be fetched keeps the summary from the listing,
+// and lookups not yet started when ctx is done are skipped.
+func (g *GitHubBaseService) lookupStargazers(ctx context.Context, stargazers []StargazerAPIData) {
+	sem := make(chan struct{}, userLookupWorkers)
+	var wg sync.WaitGroup
+
+	for i := range stargazers {
+		if user, ok := g.cachedUser(stargazers[i].User.Login); ok {
+			stargazers[i].User = user
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
 
-	if current.PullRequests > previous.LastPRCount {
-		summary.NewPRs = current.PullRequests - previous.LastPRCount
-		summary.HasChanges = true
+			lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), userLookupTimeout)
+			defer cancel()
+
+			user, err := g.client.GetUser(lookupCtx, stargazers[i].User.Login)
+			if err != nil {
+				return
+			}
+			g.cacheUser(*user)
+			stargazers[i].User = *user
+		}()
 	}
 
-	if current.Forks > previous.LastForkCount {
-		summary.NewForks = current.Forks - previous.LastForkCount
-		summary.HasChanges = true
+	wg.Wait()
+}
+
+func (g *GitHubBaseService) cachedUser(login string) (UserAPIData, bool) {
+	g.usersMu.Lock()
+	defer g.usersMu.Unlock()
+
+	user, ok := g.users[login]
+	return user, ok
+}
+
+func (g *GitHubBaseService) cacheUser(user UserAPIData) {
+	g.usersMu.Lock()
+	defer g.usersMu.Unlock()
+
+	if g.users == nil {
+		g.users = make(map[string]UserAPIData)
 	}
+	g.users[user.Login] = user
+}
+
+// GetNewIssues fetches issues and pull requests created since the given time
+func (g *GitHubBaseService) GetNewIssues(ctx context.Context, owner, repo string, since time.Time) ([]IssueAPIData, error) {
+	return g.client.GetIssuesSince(ctx, owner, repo, since)
+}
 
-	return summary
+// UpsertPinnedIssue updates the open issue carrying the draft's label, or creates and pins a new one
+func (g *GitHubBaseService) UpsertPinnedIssue(ctx context.Context, owner, repo string, draft IssueDraft) (*IssueAPIData, error) {
+	issuesPath := fmt.Sprintf("repos/%s/%s/issues", owner, repo)
+
+	var existing []IssueAPIData
+	searchPath := fmt.Sprintf("%s?state=open&per_page=1&labels=%s", issuesPath, url.QueryEscape(draft.Label))
+	if err := g.client.Get(ctx, searchPath, &existing); err != nil {
+		return nil, err
+	}
+
+	var issue IssueAPIData
+	if len(existing) > 0 {
+		body := map[string]any{"title": draft.Title, "body": draft.Body}
+		if err := g.client.Patch(ctx, fmt.Sprintf("%s/%d", issuesPath, existing[0].Number), body, &issue); err != nil {
+			return nil, err
+		}
+		return &issue, nil
+	}
+
+	body := map[string]any{"title": draft.Title, "body": draft.Body, "labels": []string{draft.Label}}
+	if err := g.client.Post(ctx, issuesPath, body, &issue); err != nil {
+		return nil, err
+	}
+
+	var pinned struct{}
+	if err := g.client.GraphQL(ctx, pinIssueMutation, map[string]any{"issueId": issue.NodeID}, &pinned); err != nil {
+		return &issue, fmt.Errorf("created issue #%d but failed to pin it: %w", issue.Number, err)
+	}
+
+	return &issue, nil
+}
+
+// GetNotifications fetches unread notifications, keeping only threads in the given repositories
+func (g *GitHubBaseService) GetNotifications(ctx context.Context, repos []string) ([]NotificationAPIData, error) {
+	notifications, err := g.client.GetNotifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	watched := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		watched[strings.ToLower(repo)] = true
+	}
+
+	var filtered []NotificationAPIData
+	for _, notification := range notifications {
+		if watched[strings.ToLower(notification.Repository.FullName)] {
+			filtered = append(filtered, notification)
+		}
+	}
+	return filtered, nil
+}
+
+// MarkNotificationRead marks a notification thread as read
+func (g *GitHubBaseService) MarkNotificationRead(ctx context.Context, threadID string) error {
+	return g.client.MarkNotificationRead(ctx, threadID)
+}
+
+// NotificationHTMLURL returns the page a notification refers to. Subjects link to the API,
+// and some, such as CI activity, have no subject URL at all.
+func NotificationHTMLURL(notification NotificationAPIData) string {
+	apiURL, ok := strings.CutPrefix(notification.Subject.URL, "https://api.github.com/repos/")
+	if !ok {
+		repo := notification.Repository.FullName
+		if notification.Reason == "ci_activity" {
+			return "https://github.com/" + repo + "/actions"
+		}
+		return "https://github.com/" + repo
+	}
+	return "https://github.com/" + strings.Replace(apiURL, "/pulls/", "/pull/", 1)
+}
+
+// RateLimit returns the most recently observed API rate limit
+func (g *GitHubBaseService) RateLimit() RateLimitInfo {
+	return g.client.RateLimit()
+}
+
+// FetchEventStates reads the current state of each watched event source, giving each source its own timeout
+func (g *GitHubBaseService) FetchEventStates(ctx context.Context, stats *RepoStats, events []string, previous RepoState, sourceTimeout time.Duration) (map[string]EventState, map[string]error) {
+	return fetchEventStates(ctx, g.client, stats, events, previous, sourceTimeout)
+}
+
+// ParseRepoString parses a repository string in the format "owner/repo"
+func ParseRepoString(repoStr string) (owner, repo string, err error) {
+	parts := strings.Split(repoStr, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", NewValidationError(
+			fmt.Sprintf("invalid repo format: %s (expected owner/repo)", repoStr),
+			repoStr,
+			nil,
+		)
+	}
+	return parts[0], parts[1], nil
 }
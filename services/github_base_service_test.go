@@ -0,0 +1,65 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+	mock_services "github.com/jackchuka/gh-oss-watch/services/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetRepoActivity_StargazerLookupsAreBestEffort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+	base := services.NewGitHubBaseService(mockClient)
+
+	since := time.Now().Add(-24 * time.Hour)
+	stargazers := func() []services.StargazerAPIData {
+		return []services.StargazerAPIData{
+			{User: services.UserAPIData{Login: "famous"}},
+			{User: services.UserAPIData{Login: "missing"}},
+		}
+	}
+	mockClient.EXPECT().GetIssuesSince(gomock.Any(), "owner", "repo", since).Return(nil, nil).Times(2)
+	mockClient.EXPECT().GetReleases(gomock.Any(), "owner", "repo").Return(nil, nil).Times(2)
+	mockClient.EXPECT().GetStargazersSince(gomock.Any(), "owner", "repo", since).DoAndReturn(
+		func(context.Context, string, string, time.Time) ([]services.StargazerAPIData, error) {
+			return stargazers(), nil
+		}).Times(2)
+
+	// Profiles are cached, so only the failed lookup is repeated
+	mockClient.EXPECT().GetUser(gomock.Any(), "famous").Return(&services.UserAPIData{Login: "famous", Followers: 5000}, nil).Times(1)
+	mockClient.EXPECT().GetUser(gomock.Any(), "missing").Return(nil, errors.New("timeout")).Times(2)
+
+	for range 2 {
+		activity, err := base.GetRepoActivity(context.Background(), "owner", "repo", since)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(activity.Stargazers) != 2 || activity.Stargazers[0].User.Followers != 5000 || activity.Stargazers[1].User.Login != "missing" {
+			t.Errorf("Expected both stargazers with the famous profile filled in, got %+v", activity.Stargazers)
+		}
+	}
+}
+
+func TestGetRepoActivity_ReturnsPartialActivity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_services.NewMockGitHubAPIClient(ctrl)
+	base := services.NewGitHubBaseService(mockClient)
+
+	since := time.Now().Add(-24 * time.Hour)
+	mockClient.EXPECT().GetIssuesSince(gomock.Any(), "owner", "repo", since).Return([]services.IssueAPIData{{Number: 1}}, nil)
+	mockClient.EXPECT().GetReleases(gomock.Any(), "owner", "repo").Return(nil, nil)
+	mockClient.EXPECT().GetStargazersSince(gomock.Any(), "owner", "repo", since).Return(nil, errors.New("rate limited"))
+
+	activity, err := base.GetRepoActivity(context.Background(), "owner", "repo", since)
+	if err == nil {
+		t.Error("Expected the stargazer error to be returned")
+	}
+	if activity == nil || len(activity.Issues) != 1 {
+		t.Errorf("Expected issues fetched before the error to be kept, got %+v", activity)
+	}
+}
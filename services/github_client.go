@@ -1,23 +1,47 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
 )
 
 type RepoAPIData struct {
-	Name            string    `json:"name"`
-	Owner           OwnerData `json:"owner"`
-	StargazersCount int       `json:"stargazers_count"`
-	ForksCount      int       `json:"forks_count"`
-	OpenIssuesCount int       `json:"open_issues_count"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	Name             string    `json:"name"`
+	Owner            OwnerData `json:"owner"`
+	StargazersCount  int       `json:"stargazers_count"`
+	ForksCount       int       `json:"forks_count"`
+	OpenIssuesCount  int       `json:"open_issues_count"`
+	SubscribersCount int       `json:"subscribers_count"`
+	DefaultBranch    string    `json:"default_branch"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	Description    string       `json:"description"`
+	Homepage       string       `json:"homepage"`
+	Topics         []string     `json:"topics"`
+	License        *LicenseData `json:"license"`
+	Visibility     string       `json:"visibility"`
+	Archived       bool         `json:"archived"`
+	Disabled       bool         `json:"disabled"`
+	HasIssues      bool         `json:"has_issues"`
+	HasDiscussions bool         `json:"has_discussions"`
+}
+
+type LicenseData struct {
+	SPDXID string `json:"spdx_id"`
+	Name   string `json:"name"`
 }
 
 type OwnerData struct {
@@ -25,19 +49,191 @@ type OwnerData struct {
 }
 
 type PullRequestAPIData struct {
-	ID     int    `json:"id"`
-	Number int    `json:"number"`
-	State  string `json:"state"`
-	Title  string `json:"title"`
+	ID        int         `json:"id"`
+	Number    int         `json:"number"`
+	State     string      `json:"state"`
+	Title     string      `json:"title"`
+	HTMLURL   string      `json:"html_url"`
+	User      UserAPIData `json:"user"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	MergedAt  *time.Time  `json:"merged_at"`
 }
 
 type UserAPIData struct {
-	Login string `json:"login"`
+	Login     string `json:"login"`
+	HTMLURL   string `json:"html_url"`
+	Followers int    `json:"followers"`
+}
+
+type IssueAPIData struct {
+	ID          int64       `json:"id"`
+	NodeID      string      `json:"node_id"`
+	Number      int         `json:"number"`
+	Title       string      `json:"title"`
+	State       string      `json:"state"`
+	HTMLURL     string      `json:"html_url"`
+	User        UserAPIData `json:"user"`
+	CreatedAt   time.Time   `json:"created_at"`
+	PullRequest *struct{}   `json:"pull_request,omitempty"`
+
+	// URL is the API URL, which comments reference as their issue_url
+	URL       string        `json:"url"`
+	Assignees []UserAPIData `json:"assignees"`
+}
+
+type IssueCommentAPIData struct {
+	ID        int64       `json:"id"`
+	Body      string      `json:"body"`
+	HTMLURL   string      `json:"html_url"`
+	IssueURL  string      `json:"issue_url"`
+	User      UserAPIData `json:"user"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type ReleaseAPIData struct {
+	ID          int64       `json:"id"`
+	TagName     string      `json:"tag_name"`
+	Name        string      `json:"name"`
+	HTMLURL     string      `json:"html_url"`
+	Draft       bool        `json:"draft"`
+	Prerelease  bool        `json:"prerelease"`
+	Author      UserAPIData `json:"author"`
+	CreatedAt   time.Time   `json:"created_at"`
+	PublishedAt time.Time   `json:"published_at"`
+}
+
+type TagAPIData struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+type WorkflowRunAPIData struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	WorkflowID int64     `json:"workflow_id"`
+	HeadBranch string    `json:"head_branch"`
+	Event      string    `json:"event"`
+	Status     string    `json:"status"`
+	Conclusion string    `json:"conclusion"`
+	HTMLURL    string    `json:"html_url"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type DependabotAlertAPIData struct {
+	Number           int       `json:"number"`
+	HTMLURL          string    `json:"html_url"`
+	CreatedAt        time.Time `json:"created_at"`
+	SecurityAdvisory struct {
+		Summary  string `json:"summary"`
+		Severity string `json:"severity"`
+	} `json:"security_advisory"`
+	Dependency struct {
+		Package struct {
+			Name      string `json:"name"`
+			Ecosystem string `json:"ecosystem"`
+		} `json:"package"`
+	} `json:"dependency"`
+}
+
+type CodeScanningAlertAPIData struct {
+	Number    int       `json:"number"`
+	HTMLURL   string    `json:"html_url"`
+	CreatedAt time.Time `json:"created_at"`
+	Rule      struct {
+		Description           string `json:"description"`
+		Severity              string `json:"severity"`
+		SecuritySeverityLevel string `json:"security_severity_level"`
+	} `json:"rule"`
+}
+
+type SecretScanningAlertAPIData struct {
+	Number                int       `json:"number"`
+	HTMLURL               string    `json:"html_url"`
+	CreatedAt             time.Time `json:"created_at"`
+	SecretTypeDisplayName string    `json:"secret_type_display_name"`
+}
+
+type TrafficCountAPIData struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int       `json:"count"`
+	Uniques   int       `json:"uniques"`
+}
+
+type TrafficPopularAPIData struct {
+	Referrer string `json:"referrer"`
+	Path     string `json:"path"`
+	Title    string `json:"title"`
+	Count    int    `json:"count"`
+	Uniques  int    `json:"uniques"`
+}
+
+// TrafficSeriesAPIData is a 14-day total with its daily points
+type TrafficSeriesAPIData struct {
+	Count   int
+	Uniques int
+	Days    []TrafficCountAPIData
+}
+
+// TrafficAPIData combines the four traffic endpoints, which all cover the last 14 days
+type TrafficAPIData struct {
+	Views     TrafficSeriesAPIData
+	Clones    TrafficSeriesAPIData
+	Referrers []TrafficPopularAPIData
+	Paths     []TrafficPopularAPIData
+}
+
+type NotificationAPIData struct {
+	ID        string    `json:"id"`
+	Reason    string    `json:"reason"`
+	Unread    bool      `json:"unread"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Subject   struct {
+		Title string `json:"title"`
+		URL   string `json:"url"`
+		Type  string `json:"type"`
+	} `json:"subject"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+type StargazerAPIData struct {
+	StarredAt time.Time   `json:"starred_at"`
+	User      UserAPIData `json:"user"`
+}
+
+// maxStargazerPages bounds how far back GetStargazersSince pages through stargazers
+const maxStargazerPages = 5
+
+var lastPagePattern = regexp.MustCompile(`[?&]page=(\d+)>; rel="last"`)
+
+// maxListPages bounds how many pages getAllPages follows for a single listing
+const maxListPages = 10
+
+var nextPagePattern = regexp.MustCompile(`<([^>]+)>; rel="next"`)
+
+type RateLimitInfo struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	UpdatedAt time.Time
 }
 
 type GitHubAPIClientImpl struct {
 	client      *api.RESTClient
+	starClient  *api.RESTClient
+	graphQL     *api.GraphQLClient
 	retryConfig RetryConfig
+
+	rateLimitMu sync.Mutex
+	rateLimit   RateLimitInfo
+
+	// viewer caches the authenticated user, which does not change while running
+	viewerMu sync.Mutex
+	viewer   *UserAPIData
 }
 
 func NewGitHubAPIClient() (GitHubAPIClient, error) {
@@ -46,26 +242,81 @@ func NewGitHubAPIClient() (GitHubAPIClient, error) {
 		return nil, NewConfigError("failed to create GitHub API client", err)
 	}
 
+	// Stargazer timestamps are only returned with the star+json media type
+	starClient, err := api.NewRESTClient(api.ClientOptions{
+		Headers: map[string]string{"Accept": "application/vnd.github.star+json"},
+	})
+	if err != nil {
+		return nil, NewConfigError("failed to create GitHub API client", err)
+	}
+
+	graphQLClient, err := api.DefaultGraphQLClient()
+	if err != nil {
+		return nil, NewConfigError("failed to create GitHub GraphQL client", err)
+	}
+
 	return &GitHubAPIClientImpl{
 		client:      restClient,
+		starClient:  starClient,
+		graphQL:     graphQLClient,
 		retryConfig: DefaultRetryConfig(),
 	}, nil
 }
 
 func (c *GitHubAPIClientImpl) Get(ctx context.Context, path string, response any) error {
+	return c.do(ctx, c.client, http.MethodGet, path, nil, response)
+}
+
+func (c *GitHubAPIClientImpl) Post(ctx context.Context, path string, body any, response any) error {
+	return c.do(ctx, c.client, http.MethodPost, path, body, response)
+}
+
+func (c *GitHubAPIClientImpl) Patch(ctx context.Context, path string, body any, response any) error {
+	return c.do(ctx, c.client, http.MethodPatch, path, body, response)
+}
+
+func (c *GitHubAPIClientImpl) GraphQL(ctx context.Context, query string, variables map[string]any, response any) error {
+	return WithRetry(ctx, c.retryConfig, func() error {
+		if err := c.graphQL.DoWithContext(ctx, query, variables, response); err != nil {
+			return c.handleAPIError(err, "")
+		}
+		return nil
+	})
+}
+
+func (c *GitHubAPIClientImpl) do(ctx context.Context, client *api.RESTClient, method, path string, body any, response any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return NewValidationError("failed to encode request body", "", err)
+		}
+	}
+
 	return WithRetry(ctx, c.retryConfig, func() error {
-		resp, err := c.client.RequestWithContext(ctx, "GET", path, nil)
+		var reader io.Reader
+		if payload != nil {
+			reader = bytes.NewReader(payload)
+		}
+
+		resp, err := client.RequestWithContext(ctx, method, path, reader)
 		if err != nil {
 			return c.handleAPIError(err, "")
 		}
 		defer func() {
 			_ = resp.Body.Close()
 		}()
+		c.recordRateLimit(resp.Header)
 
 		if resp.StatusCode >= 400 {
 			return c.handleHTTPError(resp.StatusCode, "", nil)
 		}
 
+		if response == nil || resp.StatusCode == http.StatusNoContent {
+			return nil
+		}
+
 		decoder := json.NewDecoder(resp.Body)
 		if err := decoder.Decode(response); err != nil {
 			return NewAPIError("failed to decode JSON response", resp.StatusCode, "", err)
@@ -81,11 +332,7 @@ func (c *GitHubAPIClientImpl) GetRepoData(ctx context.Context, owner, repo strin
 
 	err := c.Get(ctx, repoPath, &repoData)
 	if err != nil {
-		if ghErr, ok := err.(*GitHubError); ok {
-			ghErr.Repo = fmt.Sprintf("%s/%s", owner, repo)
-			return nil, ghErr
-		}
-		return nil, NewAPIError("failed to fetch repository data", 0, fmt.Sprintf("%s/%s", owner, repo), err)
+		return nil, c.wrapRepoError(err, "failed to fetch repository data", owner, repo)
 	}
 
 	return &repoData, nil
@@ -97,16 +344,452 @@ func (c *GitHubAPIClientImpl) GetPullRequests(ctx context.Context, owner, repo s
 
 	err := c.Get(ctx, prPath, &prs)
 	if err != nil {
-		if ghErr, ok := err.(*GitHubError); ok {
-			ghErr.Repo = fmt.Sprintf("%s/%s", owner, repo)
-			return nil, ghErr
-		}
-		return nil, NewAPIError("failed to fetch pull requests", 0, fmt.Sprintf("%s/%s", owner, repo), err)
+		return nil, c.wrapRepoError(err, "failed to fetch pull requests", owner, repo)
 	}
 
 	return prs, nil
 }
 
+// GetMergedPullRequestsSince lists pull requests merged since the given time, paging through
+// closed pull requests by most recently updated until they were last updated before it
+func (c *GitHubAPIClientImpl) GetMergedPullRequestsSince(ctx context.Context, owner, repo string, since time.Time) ([]PullRequestAPIData, error) {
+	prPath := fmt.Sprintf("repos/%s/%s/pulls?state=closed&sort=updated&direction=desc&per_page=100", owner, repo)
+
+	prs, err := getAllPages(ctx, c, prPath, func(page []PullRequestAPIData) bool {
+		return len(page) > 0 && !page[len(page)-1].UpdatedAt.Before(since)
+	})
+	if err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch pull requests", owner, repo)
+	}
+
+	merged := prs[:0]
+	for _, pr := range prs {
+		if pr.MergedAt != nil && pr.MergedAt.After(since) {
+			merged = append(merged, pr)
+		}
+	}
+	return merged, nil
+}
+
+// CountMergedPullRequests counts an author's merged pull requests in a repository using search
+func (c *GitHubAPIClientImpl) CountMergedPullRequests(ctx context.Context, owner, repo, author string) (int, error) {
+	query := fmt.Sprintf("repo:%s/%s is:pr is:merged author:%s", owner, repo, author)
+	var result struct {
+		TotalCount int `json:"total_count"`
+	}
+
+	err := c.Get(ctx, "search/issues?per_page=1&q="+url.QueryEscape(query), &result)
+	if err != nil {
+		return 0, c.wrapRepoError(err, "failed to search pull requests", owner, repo)
+	}
+
+	return result.TotalCount, nil
+}
+
+// GetContributors lists the 100 contributors with the most commits to a repository
+func (c *GitHubAPIClientImpl) GetContributors(ctx context.Context, owner, repo string) ([]UserAPIData, error) {
+	contributorsPath := fmt.Sprintf("repos/%s/%s/contributors?per_page=100", owner, repo)
+	var contributors []UserAPIData
+
+	err := c.Get(ctx, contributorsPath, &contributors)
+	if err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch contributors", owner, repo)
+	}
+
+	return contributors, nil
+}
+
+func (c *GitHubAPIClientImpl) GetIssuesSince(ctx context.Context, owner, repo string, since time.Time) ([]IssueAPIData, error) {
+	issuesPath := fmt.Sprintf("repos/%s/%s/issues?state=all&sort=created&direction=desc&per_page=100&since=%s",
+		owner, repo, url.QueryEscape(since.UTC().Format(time.RFC3339)))
+	var issues []IssueAPIData
+
+	err := c.Get(ctx, issuesPath, &issues)
+	if err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch issues", owner, repo)
+	}
+
+	// The since parameter filters by update time, so keep only issues created in the window
+	created := issues[:0]
+	for _, issue := range issues {
+		if !issue.CreatedAt.Before(since) {
+			created = append(created, issue)
+		}
+	}
+
+	return created, nil
+}
+
+// GetIssuesUpdatedSince lists issues and pull requests with any activity since the given time
+func (c *GitHubAPIClientImpl) GetIssuesUpdatedSince(ctx context.Context, owner, repo string, since time.Time) ([]IssueAPIData, error) {
+	issuesPath := fmt.Sprintf("repos/%s/%s/issues?state=all&sort=updated&direction=desc&per_page=100&since=%s",
+		owner, repo, url.QueryEscape(since.UTC().Format(time.RFC3339)))
+	var issues []IssueAPIData
+
+	err := c.Get(ctx, issuesPath, &issues)
+	if err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch issues", owner, repo)
+	}
+
+	return issues, nil
+}
+
+// GetIssueCommentsSince lists issue and pull request comments created since the given time
+func (c *GitHubAPIClientImpl) GetIssueCommentsSince(ctx context.Context, owner, repo string, since time.Time) ([]IssueCommentAPIData, error) {
+	commentsPath := fmt.Sprintf("repos/%s/%s/issues/comments?sort=created&direction=desc&per_page=100&since=%s",
+		owner, repo, url.QueryEscape(since.UTC().Format(time.RFC3339)))
+	var comments []IssueCommentAPIData
+
+	err := c.Get(ctx, commentsPath, &comments)
+	if err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch issue comments", owner, repo)
+	}
+
+	// The since parameter filters by update time, so keep only comments created in the window
+	created := comments[:0]
+	for _, comment := range comments {
+		if comment.CreatedAt.After(since) {
+			created = append(created, comment)
+		}
+	}
+
+	return created, nil
+}
+
+func (c *GitHubAPIClientImpl) GetReleases(ctx context.Context, owner, repo string) ([]ReleaseAPIData, error) {
+	releasesPath := fmt.Sprintf("repos/%s/%s/releases?per_page=30", owner, repo)
+	var releases []ReleaseAPIData
+
+	err := c.Get(ctx, releasesPath, &releases)
+	if err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch releases", owner, repo)
+	}
+
+	return releases, nil
+}
+
+const tagsQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    refs(refPrefix: "refs/tags/", first: 30, orderBy: {field: TAG_COMMIT_DATE, direction: DESC}) {
+      nodes { name target { oid } }
+    }
+  }
+}`
+
+// GetTags lists the 30 most recent tags, newest first. The REST listing is sorted by name,
+// so a new tag may not be on its first page.
+func (c *GitHubAPIClientImpl) GetTags(ctx context.Context, owner, repo string) ([]TagAPIData, error) {
+	var response struct {
+		Repository struct {
+			Refs struct {
+				Nodes []struct {
+					Name   string `json:"name"`
+					Target struct {
+						OID string `json:"oid"`
+					} `json:"target"`
+				} `json:"nodes"`
+			} `json:"refs"`
+		} `json:"repository"`
+	}
+
+	err := c.GraphQL(ctx, tagsQuery, map[string]any{"owner": owner, "name": repo}, &response)
+	if err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch tags", owner, repo)
+	}
+
+	tags := make([]TagAPIData, len(response.Repository.Refs.Nodes))
+	for i, node := range response.Repository.Refs.Nodes {
+		tags[i].Name = node.Name
+		tags[i].Commit.SHA = node.Target.OID
+	}
+	return tags, nil
+}
+
+// GetWorkflowRuns lists recent completed workflow runs on a branch, newest first
+func (c *GitHubAPIClientImpl) GetWorkflowRuns(ctx context.Context, owner, repo, branch string) ([]WorkflowRunAPIData, error) {
+	runsPath := fmt.Sprintf("repos/%s/%s/actions/runs?branch=%s&status=completed&exclude_pull_requests=true&per_page=50",
+		owner, repo, url.QueryEscape(branch))
+	var response struct {
+		WorkflowRuns []WorkflowRunAPIData `json:"workflow_runs"`
+	}
+
+	err := c.Get(ctx, runsPath, &response)
+	if err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch workflow runs", owner, repo)
+	}
+
+	return response.WorkflowRuns, nil
+}
+
+func (c *GitHubAPIClientImpl) GetDependabotAlerts(ctx context.Context, owner, repo string) ([]DependabotAlertAPIData, error) {
+	alertsPath := fmt.Sprintf("repos/%s/%s/dependabot/alerts?state=open&per_page=100", owner, repo)
+
+	alerts, err := getAllPages[DependabotAlertAPIData](ctx, c, alertsPath, nil)
+	if err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch Dependabot alerts", owner, repo)
+	}
+
+	return alerts, nil
+}
+
+func (c *GitHubAPIClientImpl) GetCodeScanningAlerts(ctx context.Context, owner, repo string) ([]CodeScanningAlertAPIData, error) {
+	alertsPath := fmt.Sprintf("repos/%s/%s/code-scanning/alerts?state=open&per_page=100", owner, repo)
+
+	alerts, err := getAllPages[CodeScanningAlertAPIData](ctx, c, alertsPath, nil)
+	if err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch code scanning alerts", owner, repo)
+	}
+
+	return alerts, nil
+}
+
+func (c *GitHubAPIClientImpl) GetSecretScanningAlerts(ctx context.Context, owner, repo string) ([]SecretScanningAlertAPIData, error) {
+	alertsPath := fmt.Sprintf("repos/%s/%s/secret-scanning/alerts?state=open&per_page=100", owner, repo)
+
+	alerts, err := getAllPages[SecretScanningAlertAPIData](ctx, c, alertsPath, nil)
+	if err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch secret scanning alerts", owner, repo)
+	}
+
+	return alerts, nil
+}
+
+// GetTraffic fetches daily views and clones with the popular referrers and paths; it needs push access
+func (c *GitHubAPIClientImpl) GetTraffic(ctx context.Context, owner, repo string) (*TrafficAPIData, error) {
+	trafficPath := fmt.Sprintf("repos/%s/%s/traffic", owner, repo)
+	traffic := &TrafficAPIData{}
+
+	var views struct {
+		Count   int                   `json:"count"`
+		Uniques int                   `json:"uniques"`
+		Views   []TrafficCountAPIData `json:"views"`
+	}
+	if err := c.Get(ctx, trafficPath+"/views?per=day", &views); err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch traffic views", owner, repo)
+	}
+	traffic.Views = TrafficSeriesAPIData{Count: views.Count, Uniques: views.Uniques, Days: views.Views}
+
+	var clones struct {
+		Count   int                   `json:"count"`
+		Uniques int                   `json:"uniques"`
+		Clones  []TrafficCountAPIData `json:"clones"`
+	}
+	if err := c.Get(ctx, trafficPath+"/clones?per=day", &clones); err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch traffic clones", owner, repo)
+	}
+	traffic.Clones = TrafficSeriesAPIData{Count: clones.Count, Uniques: clones.Uniques, Days: clones.Clones}
+
+	if err := c.Get(ctx, trafficPath+"/popular/referrers", &traffic.Referrers); err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch traffic referrers", owner, repo)
+	}
+	if err := c.Get(ctx, trafficPath+"/popular/paths", &traffic.Paths); err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch traffic paths", owner, repo)
+	}
+
+	return traffic, nil
+}
+
+func (c *GitHubAPIClientImpl) GetStargazersSince(ctx context.Context, owner, repo string, since time.Time) ([]StargazerAPIData, error) {
+	stargazersPath := fmt.Sprintf("repos/%s/%s/stargazers?per_page=100", owner, repo)
+
+	// Stargazers are listed oldest first, so start from the last page and walk backwards
+	var firstPage []StargazerAPIData
+	lastPage, err := c.getStargazerPage(ctx, stargazersPath, &firstPage)
+	if err != nil {
+		return nil, c.wrapRepoError(err, "failed to fetch stargazers", owner, repo)
+	}
+
+	var stargazers []StargazerAPIData
+	for page := lastPage; page > 1 && lastPage-page < maxStargazerPages; page-- {
+		var pageData []StargazerAPIData
+		if _, err := c.getStargazerPage(ctx, fmt.Sprintf("%s&page=%d", stargazersPath, page), &pageData); err != nil {
+			return nil, c.wrapRepoError(err, "failed to fetch stargazers", owner, repo)
+		}
+
+		recent := filterStargazersSince(pageData, since)
+		stargazers = append(recent, stargazers...)
+		if len(recent) < len(pageData) {
+			return stargazers, nil
+		}
+	}
+
+	if lastPage <= maxStargazerPages {
+		stargazers = append(filterStargazersSince(firstPage, since), stargazers...)
+	}
+
+	return stargazers, nil
+}
+
+func (c *GitHubAPIClientImpl) getStargazerPage(ctx context.Context, path string, response *[]StargazerAPIData) (int, error) {
+	lastPage := 1
+
+	err := WithRetry(ctx, c.retryConfig, func() error {
+		resp, err := c.starClient.RequestWithContext(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return c.handleAPIError(err, "")
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		c.recordRateLimit(resp.Header)
+
+		if match := lastPagePattern.FindStringSubmatch(resp.Header.Get("Link")); match != nil {
+			if page, err := strconv.Atoi(match[1]); err == nil {
+				lastPage = page
+			}
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+			return NewAPIError("failed to decode JSON response", resp.StatusCode, "", err)
+		}
+
+		return nil
+	})
+
+	return lastPage, err
+}
+
+// getAllPages reads a listing page by page, following the Link headers for up to maxListPages
+// pages. It stops early when more returns false for a page.
+func getAllPages[T any](ctx context.Context, c *GitHubAPIClientImpl, path string, more func(page []T) bool) ([]T, error) {
+	var items []T
+	for page := 0; path != "" && page < maxListPages; page++ {
+		var pageData []T
+		next, err := c.getPage(ctx, path, &pageData)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, pageData...)
+		if more != nil && !more(pageData) {
+			break
+		}
+		path = next
+	}
+	return items, nil
+}
+
+// getPage fetches one page of a listing, returning the URL of the next page or "" on the last
+func (c *GitHubAPIClientImpl) getPage(ctx context.Context, path string, response any) (string, error) {
+	var next string
+
+	err := WithRetry(ctx, c.retryConfig, func() error {
+		resp, err := c.client.RequestWithContext(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return c.handleAPIError(err, "")
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		c.recordRateLimit(resp.Header)
+
+		if resp.StatusCode >= 400 {
+			return c.handleHTTPError(resp.StatusCode, "", nil)
+		}
+
+		next = ""
+		if match := nextPagePattern.FindStringSubmatch(resp.Header.Get("Link")); match != nil {
+			next = match[1]
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+			return NewAPIError("failed to decode JSON response", resp.StatusCode, "", err)
+		}
+
+		return nil
+	})
+
+	return next, err
+}
+
+func filterStargazersSince(stargazers []StargazerAPIData, since time.Time) []StargazerAPIData {
+	var recent []StargazerAPIData
+	for _, stargazer := range stargazers {
+		if !stargazer.StarredAt.Before(since) {
+			recent = append(recent, stargazer)
+		}
+	}
+	return recent
+}
+
+func (c *GitHubAPIClientImpl) GetUser(ctx context.Context, login string) (*UserAPIData, error) {
+	var user UserAPIData
+
+	err := c.Get(ctx, fmt.Sprintf("users/%s", login), &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// GetNotifications lists the authenticated user's unread notifications, most recent first,
+// following their pages
+func (c *GitHubAPIClientImpl) GetNotifications(ctx context.Context) ([]NotificationAPIData, error) {
+	notifications, err := getAllPages[NotificationAPIData](ctx, c, "notifications?per_page=100", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// MarkNotificationRead marks a single notification thread as read
+func (c *GitHubAPIClientImpl) MarkNotificationRead(ctx context.Context, threadID string) error {
+	return c.Patch(ctx, "notifications/threads/"+url.PathEscape(threadID), nil, nil)
+}
+
+// GetAuthenticatedUser returns the user the client is authenticated as, fetching it once
+func (c *GitHubAPIClientImpl) GetAuthenticatedUser(ctx context.Context) (*UserAPIData, error) {
+	c.viewerMu.Lock()
+	defer c.viewerMu.Unlock()
+
+	if c.viewer != nil {
+		return c.viewer, nil
+	}
+
+	var user UserAPIData
+	if err := c.Get(ctx, "user", &user); err != nil {
+		return nil, err
+	}
+
+	c.viewer = &user
+	return c.viewer, nil
+}
+
+// RateLimit returns the rate limit reported by the most recent API response
+func (c *GitHubAPIClientImpl) RateLimit() RateLimitInfo {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	return c.rateLimit
+}
+
+func (c *GitHubAPIClientImpl) recordRateLimit(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	c.rateLimit = RateLimitInfo{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (c *GitHubAPIClientImpl) wrapRepoError(err error, message, owner, repo string) error {
+	if ghErr, ok := err.(*GitHubError); ok {
+		ghErr.Repo = fmt.Sprintf("%s/%s", owner, repo)
+		return ghErr
+	}
+	return NewAPIError(message, 0, fmt.Sprintf("%s/%s", owner, repo), err)
+}
+
 func (c *GitHubAPIClientImpl) handleHTTPError(statusCode int, repo string, err error) error {
 	switch statusCode {
 	case http.StatusUnauthorized:
@@ -131,6 +814,24 @@ func (c *GitHubAPIClientImpl) handleAPIError(err error, repo string) error {
 		return nil
 	}
 
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		c.recordRateLimit(httpErr.Headers)
+		// GitHub reports an exhausted primary rate limit as 403 with no remaining requests,
+		// and a secondary rate limit as 403 asking to retry later
+		if httpErr.StatusCode == http.StatusForbidden && (httpErr.Headers.Get("X-RateLimit-Remaining") == "0" ||
+			httpErr.Headers.Get("Retry-After") != "" || strings.Contains(strings.ToLower(httpErr.Message), "secondary rate limit")) {
+			return &GitHubError{
+				Type:       ErrorTypeRateLimit,
+				Message:    "rate limit exceeded",
+				StatusCode: httpErr.StatusCode,
+				Repo:       repo,
+				Underlying: err,
+			}
+		}
+		return c.handleHTTPError(httpErr.StatusCode, repo, err)
+	}
+
 	errStr := err.Error()
 
 	if strings.Contains(errStr, "timeout") || strings.Contains(errStr, "context deadline") {
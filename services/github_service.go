@@ -30,6 +30,53 @@ func (g *GitHubServiceImpl) GetRepoStats(owner, repo string) (*RepoStats, error)
 	return g.baseService.GetRepoStats(ctx, owner, repo)
 }
 
+func (g *GitHubServiceImpl) GetRepoActivity(owner, repo string, since time.Time) (*RepoActivity, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+
+	return g.baseService.GetRepoActivity(ctx, owner, repo, since)
+}
+
+func (g *GitHubServiceImpl) GetNewIssues(owner, repo string, since time.Time) ([]IssueAPIData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+
+	return g.baseService.GetNewIssues(ctx, owner, repo, since)
+}
+
+func (g *GitHubServiceImpl) FetchEventStates(stats *RepoStats, events []string, previous RepoState) (map[string]EventState, map[string]error) {
+	return g.baseService.FetchEventStates(context.Background(), stats, events, previous, g.timeout)
+}
+
+func (g *GitHubServiceImpl) UpsertPinnedIssue(owner, repo string, draft IssueDraft) (*IssueAPIData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+
+	return g.baseService.UpsertPinnedIssue(ctx, owner, repo, draft)
+}
+
+func (g *GitHubServiceImpl) GetNotifications(repos []string) ([]NotificationAPIData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+
+	return g.baseService.GetNotifications(ctx, repos)
+}
+
+func (g *GitHubServiceImpl) MarkNotificationsRead(threadIDs []string) []error {
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+
+	errs := make([]error, len(threadIDs))
+	for i, id := range threadIDs {
+		errs[i] = g.baseService.MarkNotificationRead(ctx, id)
+	}
+	return errs
+}
+
+func (g *GitHubServiceImpl) RateLimit() RateLimitInfo {
+	return g.baseService.RateLimit()
+}
+
 func (g *GitHubServiceImpl) SetMaxConcurrent(maxConcurrent int) {
 	// No-op for sequential service
 }
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxSnapshotsPerRepo bounds how many snapshots are kept for a single repository
+const maxSnapshotsPerRepo = 1000
+
+// maxHistoryEvents bounds how many events are kept across all repositories
+const maxHistoryEvents = 2000
+
+// maxTrafficSnapshots bounds how many daily referrer and path snapshots are kept per repository
+const maxTrafficSnapshots = 366
+
+// Item event types; count events use the name of the watched event, e.g. "stars".
+// Event sources define the types of the items they record next to the source.
+const (
+	HistoryEventIssue       = "issue"
+	HistoryEventPullRequest = "pull_request"
+)
+
+type HistoryServiceImpl struct{}
+
+func NewHistoryService() HistoryService {
+	return &HistoryServiceImpl{}
+}
+
+func (h *HistoryServiceImpl) Load() (*HistoryData, error) {
+	historyPath, err := h.getHistoryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(historyPath); os.IsNotExist(err) {
+		return &HistoryData{
+			Repos: make(map[string][]RepoSnapshot),
+		}, nil
+	}
+
+	data, err := os.ReadFile(historyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var history HistoryData
+	err = yaml.Unmarshal(data, &history)
+	if err != nil {
+		return nil, err
+	}
+
+	if history.Repos == nil {
+		history.Repos = make(map[string][]RepoSnapshot)
+	}
+
+	return &history, nil
+}
+
+func (h *HistoryServiceImpl) Save(history *HistoryData) error {
+	configDir, err := h.getConfigDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+
+	historyPath, err := h.getHistoryPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(history)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(historyPath, data, 0644)
+}
+
+func (h *HistoryServiceImpl) getHistoryPath() (string, error) {
+	configDir, err := h.getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "history.yaml"), nil
+}
+
+func (h *HistoryServiceImpl) getConfigDir() (string, error) {
+	if dir := os.Getenv(StateDirEnv); dir != "" {
+		return dir, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".gh-oss-watch"), nil
+}
+
+// Record appends a snapshot of the given stats to the repository's history
+func (h *HistoryData) Record(repo string, stats *RepoStats, at time.Time) {
+	if h.Repos == nil {
+		h.Repos = make(map[string][]RepoSnapshot)
+	}
+
+	snapshots := append(h.Repos[repo], RepoSnapshot{
+		Time:         at,
+		Stars:        stats.Stars,
+		Issues:       stats.Issues,
+		PullRequests: stats.PullRequests,
+		Forks:        stats.Forks,
+	})
+
+	if len(snapshots) > maxSnapshotsPerRepo {
+		snapshots = snapshots[len(snapshots)-maxSnapshotsPerRepo:]
+	}
+
+	h.Repos[repo] = snapshots
+}
+
+// Latest returns the most recent snapshot recorded for a repository
+func (h *HistoryData) Latest(repo string) (RepoSnapshot, bool) {
+	snapshots := h.Repos[repo]
+	if len(snapshots) == 0 {
+		return RepoSnapshot{}, false
+	}
+	return snapshots[len(snapshots)-1], true
+}
+
+// AddEvent appends an event unless one with the same ID was already recorded
+func (h *HistoryData) AddEvent(event HistoryEvent) bool {
+	for _, existing := range h.Events {
+		if existing.ID == event.ID {
+			return false
+		}
+	}
+
+	h.Events = append(h.Events, event)
+	if len(h.Events) > maxHistoryEvents {
+		h.Events = h.Events[len(h.Events)-maxHistoryEvents:]
+	}
+	return true
+}
+
+// MergeTraffic merges daily traffic points into the repository's archive, replacing days
+// already recorded since GitHub revises the current day, and keeps one referrer and path
+// snapshot per day
+func (h *HistoryData) MergeTraffic(repo string, days []TrafficDay, referrers, paths []TrafficEntry, at time.Time) {
+	if h.Traffic == nil {
+		h.Traffic = make(map[string]TrafficHistory)
+	}
+	traffic := h.Traffic[repo]
+
+	for _, day := range days {
+		i, found := slices.BinarySearchFunc(traffic.Days, day.Date, func(d TrafficDay, date time.Time) int {
+			return d.Date.Compare(date)
+		})
+		if found {
+			traffic.Days[i] = day
+		} else {
+			traffic.Days = slices.Insert(traffic.Days, i, day)
+		}
+	}
+
+	date := at.UTC().Truncate(24 * time.Hour)
+	traffic.Referrers = addTrafficSnapshot(traffic.Referrers, TrafficSnapshot{Date: date, Entries: referrers})
+	traffic.Paths = addTrafficSnapshot(traffic.Paths, TrafficSnapshot{Date: date, Entries: paths})
+
+	h.Traffic[repo] = traffic
+}
+
+func addTrafficSnapshot(snapshots []TrafficSnapshot, snapshot TrafficSnapshot) []TrafficSnapshot {
+	if n := len(snapshots); n > 0 && snapshots[n-1].Date.Equal(snapshot.Date) {
+		snapshots[n-1] = snapshot
+	} else {
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if len(snapshots) > maxTrafficSnapshots {
+		snapshots = snapshots[len(snapshots)-maxTrafficSnapshots:]
+	}
+	return snapshots
+}
+
+// NewCountEvent creates an event summarizing a batch of changes, such as new stars
+func NewCountEvent(repo, eventType string, count int, at time.Time) HistoryEvent {
+	return HistoryEvent{
+		ID:    fmt.Sprintf("%s/%s/%d", repo, eventType, at.Unix()),
+		Repo:  repo,
+		Type:  eventType,
+		Time:  at,
+		Count: count,
+	}
+}
+
+// NewIssueEvent creates an event for a single issue or pull request
+func NewIssueEvent(repo string, issue IssueAPIData) HistoryEvent {
+	eventType := HistoryEventIssue
+	if issue.PullRequest != nil {
+		eventType = HistoryEventPullRequest
+	}
+
+	return HistoryEvent{
+		ID:     fmt.Sprintf("%s/%s/%d", repo, eventType, issue.Number),
+		Repo:   repo,
+		Type:   eventType,
+		Time:   issue.CreatedAt,
+		Number: issue.Number,
+		Title:  issue.Title,
+		URL:    issue.HTMLURL,
+		Author: issue.User.Login,
+	}
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Hook triggers, also passed to hooks as OSSW_EVENT
+const (
+	HookOnChange    = "on_change"
+	HookOnError     = "on_error"
+	HookOnThreshold = "on_threshold"
+)
+
+const (
+	defaultHookTimeout       = 30 * time.Second
+	defaultHookMaxConcurrent = 4
+	maxHookOutput            = 512
+)
+
+// HookCommand is a command line given either as an argument list or as a string run by the shell
+type HookCommand []string
+
+func (h *HookCommand) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*h = HookCommand{"sh", "-c", value.Value}
+		return nil
+	}
+
+	var args []string
+	if err := value.Decode(&args); err != nil {
+		return err
+	}
+	*h = args
+	return nil
+}
+
+// HookPayload is written as JSON to each hook's stdin
+type HookPayload struct {
+	Event   string     `json:"event"`
+	Hook    string     `json:"hook"`
+	Matched []string   `json:"matched_repos,omitempty"`
+	Run     *RunResult `json:"run"`
+}
+
+type hookInvocation struct {
+	event   string
+	config  HookConfig
+	timeout time.Duration
+	matched []string
+}
+
+// HookRunner runs user commands when a run has changes, errors or crosses thresholds
+type HookRunner struct {
+	config HooksConfig
+}
+
+func NewHookRunner(config HooksConfig) (*HookRunner, error) {
+	for event, hooks := range config.byEvent() {
+		for i, hook := range hooks {
+			if len(hook.Command) == 0 {
+				return nil, NewConfigError(fmt.Sprintf("hook %s[%d]: command is required", event, i), nil)
+			}
+			if hook.Timeout != "" {
+				if _, err := ParseDuration(hook.Timeout); err != nil {
+					return nil, NewConfigError(fmt.Sprintf("hook %s[%d]: invalid timeout", event, i), err)
+				}
+			}
+		}
+	}
+
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = defaultHookMaxConcurrent
+	}
+
+	return &HookRunner{config: config}, nil
+}
+
+func (c HooksConfig) hasHooks() bool {
+	return len(c.OnChange)+len(c.OnError)+len(c.OnThreshold) > 0
+}
+
+func (c HooksConfig) byEvent() map[string][]HookConfig {
+	return map[string][]HookConfig{
+		HookOnChange:    c.OnChange,
+		HookOnError:     c.OnError,
+		HookOnThreshold: c.OnThreshold,
+	}
+}
+
+func (h *HookRunner) Name() string {
+	return "hooks"
+}
+
+func (h *HookRunner) Notify(ctx context.Context, run *RunResult) error {
+	invocations := h.triggered(run)
+	if len(invocations) == 0 {
+		return nil
+	}
+
+	sem := make(chan struct{}, h.config.MaxConcurrent)
+	errs := make([]error, len(invocations))
+
+	var wg sync.WaitGroup
+	for i, invocation := range invocations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			errs[i] = runHook(ctx, invocation, run)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// triggered lists the hooks that should run for this result
+func (h *HookRunner) triggered(run *RunResult) []hookInvocation {
+	var invocations []hookInvocation
+
+	add := func(event string, hook HookConfig, matched []string) {
+		timeout := defaultHookTimeout
+		if hook.Timeout != "" {
+			timeout, _ = ParseDuration(hook.Timeout)
+		}
+		invocations = append(invocations, hookInvocation{event: event, config: hook, timeout: timeout, matched: matched})
+	}
+
+	if run.HasChanges() {
+		for _, hook := range h.config.OnChange {
+			add(HookOnChange, hook, repoNames(run.Changed()))
+		}
+	}
+
+	if failed := run.Failed(); len(failed) > 0 {
+		for _, hook := range h.config.OnError {
+			add(HookOnError, hook, repoNames(failed))
+		}
+	}
+
+	for _, hook := range h.config.OnThreshold {
+		var matched []string
+		for _, repo := range run.Repos {
+			for event, threshold := range hook.Thresholds {
+				if threshold > 0 && repo.Delta(event) >= threshold {
+					matched = append(matched, repo.Repo)
+					break
+				}
+			}
+		}
+		if len(matched) > 0 {
+			add(HookOnThreshold, hook, matched)
+		}
+	}
+
+	return invocations
+}
+
+func runHook(ctx context.Context, invocation hookInvocation, run *RunResult) error {
+	name := invocation.config.Name
+	if name == "" {
+		name = strings.Join(invocation.config.Command, " ")
+	}
+
+	payload, err := json.Marshal(HookPayload{
+		Event:   invocation.event,
+		Hook:    name,
+		Matched: invocation.matched,
+		Run:     run,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, invocation.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, invocation.config.Command[0], invocation.config.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), hookEnv(invocation, run)...)
+	cmd.WaitDelay = time.Second
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err = cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return NewTimeoutError(fmt.Sprintf("hook %q timed out after %s", name, invocation.timeout), "", err)
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("hook %q exited with code %d: %s", name, exitErr.ExitCode(), tailOutput(output.String()))
+	}
+	if err != nil {
+		return fmt.Errorf("hook %q failed to start: %w", name, err)
+	}
+
+	return nil
+}
+
+// hookEnv exposes key facts about the run so simple hooks do not need to parse JSON
+func hookEnv(invocation hookInvocation, run *RunResult) []string {
+	totals := map[string]int{}
+	for _, repo := range run.Repos {
+		for _, event := range EventSourceNames() {
+			totals[event] += repo.Delta(event)
+		}
+	}
+
+	changed := repoNames(run.Changed())
+	failed := repoNames(run.Failed())
+
+	env := []string{
+		"OSSW_EVENT=" + invocation.event,
+		"OSSW_CHECKED_AT=" + run.CheckedAt.Format(time.RFC3339),
+		"OSSW_HAS_CHANGES=" + strconv.FormatBool(run.HasChanges()),
+		"OSSW_CHANGED_REPOS=" + strings.Join(changed, ","),
+		"OSSW_CHANGED_COUNT=" + strconv.Itoa(len(changed)),
+		"OSSW_FAILED_REPOS=" + strings.Join(failed, ","),
+		"OSSW_FAILED_COUNT=" + strconv.Itoa(len(failed)),
+		"OSSW_MATCHED_REPOS=" + strings.Join(invocation.matched, ","),
+	}
+	for _, event := range EventSourceNames() {
+		env = append(env, "OSSW_NEW_"+strings.ToUpper(event)+"="+strconv.Itoa(totals[event]))
+	}
+	return env
+}
+
+func repoNames(repos []RepoRunResult) []string {
+	names := make([]string, len(repos))
+	for i, repo := range repos {
+		names[i] = repo.Repo
+	}
+	return names
+}
+
+func tailOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > maxHookOutput {
+		output = "…" + output[len(output)-maxHookOutput:]
+	}
+	if output == "" {
+		return "(no output)"
+	}
+	return output
+}
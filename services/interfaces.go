@@ -18,10 +18,37 @@ type CacheService interface {
 	Save(cache *CacheData) error
 }
 
+type HistoryService interface {
+	Load() (*HistoryData, error)
+	Save(history *HistoryData) error
+}
+
 type GitHubAPIClient interface {
 	Get(ctx context.Context, path string, response any) error
+	Post(ctx context.Context, path string, body any, response any) error
+	Patch(ctx context.Context, path string, body any, response any) error
+	GraphQL(ctx context.Context, query string, variables map[string]any, response any) error
 	GetRepoData(ctx context.Context, owner, repo string) (*RepoAPIData, error)
 	GetPullRequests(ctx context.Context, owner, repo string) ([]PullRequestAPIData, error)
+	GetMergedPullRequestsSince(ctx context.Context, owner, repo string, since time.Time) ([]PullRequestAPIData, error)
+	CountMergedPullRequests(ctx context.Context, owner, repo, author string) (int, error)
+	GetContributors(ctx context.Context, owner, repo string) ([]UserAPIData, error)
+	GetIssuesSince(ctx context.Context, owner, repo string, since time.Time) ([]IssueAPIData, error)
+	GetIssuesUpdatedSince(ctx context.Context, owner, repo string, since time.Time) ([]IssueAPIData, error)
+	GetIssueCommentsSince(ctx context.Context, owner, repo string, since time.Time) ([]IssueCommentAPIData, error)
+	GetReleases(ctx context.Context, owner, repo string) ([]ReleaseAPIData, error)
+	GetTags(ctx context.Context, owner, repo string) ([]TagAPIData, error)
+	GetWorkflowRuns(ctx context.Context, owner, repo, branch string) ([]WorkflowRunAPIData, error)
+	GetDependabotAlerts(ctx context.Context, owner, repo string) ([]DependabotAlertAPIData, error)
+	GetCodeScanningAlerts(ctx context.Context, owner, repo string) ([]CodeScanningAlertAPIData, error)
+	GetSecretScanningAlerts(ctx context.Context, owner, repo string) ([]SecretScanningAlertAPIData, error)
+	GetTraffic(ctx context.Context, owner, repo string) (*TrafficAPIData, error)
+	GetStargazersSince(ctx context.Context, owner, repo string, since time.Time) ([]StargazerAPIData, error)
+	GetUser(ctx context.Context, login string) (*UserAPIData, error)
+	GetAuthenticatedUser(ctx context.Context) (*UserAPIData, error)
+	GetNotifications(ctx context.Context) ([]NotificationAPIData, error)
+	MarkNotificationRead(ctx context.Context, threadID string) error
+	RateLimit() RateLimitInfo
 }
 
 type GitHubService interface {
@@ -35,48 +62,268 @@ type BatchGitHubService interface {
 	GetRepoStatsBatch(repos []string) ([]*RepoStats, []error)
 }
 
+type ActivityGitHubService interface {
+	GitHubService
+	GetRepoActivity(owner, repo string, since time.Time) (*RepoActivity, error)
+	GetNewIssues(owner, repo string, since time.Time) ([]IssueAPIData, error)
+}
+
+type EventGitHubService interface {
+	GitHubService
+	FetchEventStates(stats *RepoStats, events []string, previous RepoState) (map[string]EventState, map[string]error)
+}
+
+// BatchEventGitHubService fetches stats and event states for many repositories in one worker pool
+type BatchEventGitHubService interface {
+	BatchGitHubService
+	GetRepoEventsBatch(requests []RepoEventsRequest) []RepoEventsResult
+}
+
+type InboxGitHubService interface {
+	GitHubService
+	GetNotifications(repos []string) ([]NotificationAPIData, error)
+	MarkNotificationsRead(threadIDs []string) []error
+}
+
+type RateLimitReporter interface {
+	RateLimit() RateLimitInfo
+}
+
+type IssuePublisher interface {
+	UpsertPinnedIssue(owner, repo string, draft IssueDraft) (*IssueAPIData, error)
+}
+
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, run *RunResult) error
+}
+
 type Output interface {
 	Printf(format string, args ...any)
 	Println(args ...any)
 }
 
 type Config struct {
-	Repos []RepoConfig `yaml:"repos"`
+	Repos     []RepoConfig        `yaml:"repos"`
+	Groups    map[string][]string `yaml:"groups,omitempty"`
+	Notifiers NotifiersConfig     `yaml:"notifiers,omitempty"`
+	Hooks     HooksConfig         `yaml:"hooks,omitempty"`
+	Rules     []RuleConfig        `yaml:"rules,omitempty"`
 }
 
 type RepoConfig struct {
-	Repo   string   `yaml:"repo"`
-	Events []string `yaml:"events"`
+	Repo     string   `yaml:"repo"`
+	Events   []string `yaml:"events"`
+	Interval string   `yaml:"interval,omitempty"`
+}
+
+type NotifiersConfig struct {
+	Webhooks []WebhookConfig `yaml:"webhooks,omitempty"`
+	Slack    []SlackConfig   `yaml:"slack,omitempty"`
+	Email    []EmailConfig   `yaml:"email,omitempty"`
+}
+
+type WebhookConfig struct {
+	Name      string            `yaml:"name"`
+	URL       string            `yaml:"url"`
+	SecretEnv string            `yaml:"secret_env,omitempty"`
+	Template  string            `yaml:"template,omitempty"`
+	Headers   map[string]string `yaml:"headers,omitempty"`
+}
+
+type SlackConfig struct {
+	Name           string       `yaml:"name"`
+	WebhookURL     string       `yaml:"webhook_url,omitempty"`
+	WebhookURLEnv  string       `yaml:"webhook_url_env,omitempty"`
+	DefaultChannel string       `yaml:"default_channel,omitempty"`
+	Routes         []SlackRoute `yaml:"routes,omitempty"`
+}
+
+type SlackRoute struct {
+	Repos         []string `yaml:"repos,omitempty"`
+	Groups        []string `yaml:"groups,omitempty"`
+	Channel       string   `yaml:"channel,omitempty"`
+	WebhookURL    string   `yaml:"webhook_url,omitempty"`
+	WebhookURLEnv string   `yaml:"webhook_url_env,omitempty"`
+}
+
+type EmailConfig struct {
+	Name            string   `yaml:"name"`
+	Host            string   `yaml:"host"`
+	Port            int      `yaml:"port,omitempty"`
+	From            string   `yaml:"from"`
+	To              []string `yaml:"to"`
+	Subject         string   `yaml:"subject,omitempty"`
+	UsernameEnv     string   `yaml:"username_env,omitempty"`
+	PasswordEnv     string   `yaml:"password_env,omitempty"`
+	DisableStartTLS bool     `yaml:"disable_starttls,omitempty"`
+	OnlyOnChanges   bool     `yaml:"only_on_changes,omitempty"`
+}
+
+type HooksConfig struct {
+	MaxConcurrent int          `yaml:"max_concurrent,omitempty"`
+	OnChange      []HookConfig `yaml:"on_change,omitempty"`
+	OnError       []HookConfig `yaml:"on_error,omitempty"`
+	OnThreshold   []HookConfig `yaml:"on_threshold,omitempty"`
+}
+
+type HookConfig struct {
+	Name       string         `yaml:"name,omitempty"`
+	Command    HookCommand    `yaml:"command"`
+	Timeout    string         `yaml:"timeout,omitempty"`
+	Thresholds map[string]int `yaml:"thresholds,omitempty"`
+}
+
+type RuleConfig struct {
+	Name     string   `yaml:"name"`
+	When     string   `yaml:"when,omitempty"`
+	Notify   []string `yaml:"notify,omitempty"`
+	Throttle string   `yaml:"throttle,omitempty"`
 }
 
 type CacheData struct {
 	LastCheck time.Time            `yaml:"last_check"`
 	Repos     map[string]RepoState `yaml:"repos"`
+	Throttle  map[string]time.Time `yaml:"throttle,omitempty"`
+
+	// Deliveries holds recently processed webhook delivery IDs so redeliveries are ignored
+	Deliveries map[string]time.Time `yaml:"deliveries,omitempty"`
 }
 
 type RepoState struct {
-	LastStarCount  int       `yaml:"last_star_count"`
-	LastIssueCount int       `yaml:"last_issue_count"`
-	LastPRCount    int       `yaml:"last_pr_count"`
-	LastForkCount  int       `yaml:"last_fork_count"`
-	LastUpdated    time.Time `yaml:"last_updated"`
+	Events       map[string]EventState `yaml:"events,omitempty"`
+	LastUpdated  time.Time             `yaml:"last_updated"`
+	LastFetched  time.Time             `yaml:"last_fetched,omitempty"`
+	LastActivity time.Time             `yaml:"last_activity,omitempty"`
+}
+
+type HistoryData struct {
+	Repos   map[string][]RepoSnapshot `yaml:"repos"`
+	Events  []HistoryEvent            `yaml:"events,omitempty"`
+	Traffic map[string]TrafficHistory `yaml:"traffic,omitempty"`
+}
+
+// TrafficHistory archives a repository's traffic beyond the 14 days GitHub retains
+type TrafficHistory struct {
+	Days      []TrafficDay      `yaml:"days"`
+	Referrers []TrafficSnapshot `yaml:"referrers,omitempty"`
+	Paths     []TrafficSnapshot `yaml:"paths,omitempty"`
+}
+
+type TrafficDay struct {
+	Date     time.Time `yaml:"date" json:"date"`
+	Views    int       `yaml:"views" json:"views"`
+	Visitors int       `yaml:"visitors" json:"visitors"`
+	Clones   int       `yaml:"clones" json:"clones"`
+	Cloners  int       `yaml:"cloners" json:"cloners"`
+}
+
+// TrafficSnapshot holds the top referrers or paths over the 14 days before Date
+type TrafficSnapshot struct {
+	Date    time.Time      `yaml:"date" json:"date"`
+	Entries []TrafficEntry `yaml:"entries" json:"entries"`
+}
+
+type TrafficEntry struct {
+	Name    string `yaml:"name" json:"name"`
+	Title   string `yaml:"title,omitempty" json:"title,omitempty"`
+	Count   int    `yaml:"count" json:"count"`
+	Uniques int    `yaml:"uniques" json:"uniques"`
+}
+
+type RepoSnapshot struct {
+	Time         time.Time `yaml:"time"`
+	Stars        int       `yaml:"stars"`
+	Issues       int       `yaml:"issues"`
+	PullRequests int       `yaml:"pull_requests"`
+	Forks        int       `yaml:"forks"`
+}
+
+type HistoryEvent struct {
+	ID     string    `yaml:"id" json:"id"`
+	Repo   string    `yaml:"repo" json:"repo"`
+	Type   string    `yaml:"type" json:"type"`
+	Time   time.Time `yaml:"time" json:"time"`
+	Count  int       `yaml:"count,omitempty" json:"count,omitempty"`
+	Number int       `yaml:"number,omitempty" json:"number,omitempty"`
+	Title  string    `yaml:"title,omitempty" json:"title,omitempty"`
+	URL    string    `yaml:"url,omitempty" json:"url,omitempty"`
+	Author string    `yaml:"author,omitempty" json:"author,omitempty"`
+
+	// Values holds source-specific details, such as a discussion's category; see each event source
+	Values map[string]string `yaml:"values,omitempty" json:"values,omitempty"`
 }
 
 type RepoStats struct {
-	Name         string
-	Owner        string
-	Stars        int
-	Issues       int
-	PullRequests int
-	Forks        int
-	UpdatedAt    time.Time
+	Name         string    `json:"name"`
+	Owner        string    `json:"owner"`
+	Stars        int       `json:"stars"`
+	Issues       int       `json:"issues"`
+	PullRequests int       `json:"pull_requests"`
+	Forks        int       `json:"forks"`
+	Watchers     int       `json:"watchers"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// DefaultBranch is empty when the stats did not come from the API, e.g. for webhooks
+	DefaultBranch string `json:"default_branch,omitempty"`
+
+	// Metadata is nil when the stats did not come from the API, e.g. for webhooks
+	Metadata *RepoMetadata `json:"metadata,omitempty"`
+}
+
+// RepoMetadata holds the repository settings watched by the metadata event
+type RepoMetadata struct {
+	Description    string   `json:"description"`
+	Homepage       string   `json:"homepage"`
+	Topics         []string `json:"topics"`
+	License        string   `json:"license"`
+	DefaultBranch  string   `json:"default_branch"`
+	Visibility     string   `json:"visibility"`
+	Archived       bool     `json:"archived"`
+	Disabled       bool     `json:"disabled"`
+	HasIssues      bool     `json:"has_issues"`
+	HasDiscussions bool     `json:"has_discussions"`
 }
 
 type EventSummary struct {
-	Repo       string
-	NewStars   int
-	NewIssues  int
-	NewPRs     int
-	NewForks   int
-	HasChanges bool
+	Repo       string         `json:"repo"`
+	Changes    map[string]int `json:"changes,omitempty"`
+	HasChanges bool           `json:"has_changes"`
+}
+
+// RunResult is the structured outcome of a status run, handed to notifiers
+type RunResult struct {
+	CheckedAt time.Time       `json:"checked_at"`
+	Repos     []RepoRunResult `json:"repos"`
+}
+
+type RepoRunResult struct {
+	Repo        string                `json:"repo"`
+	Events      []string              `json:"watched_events"`
+	Stats       *RepoStats            `json:"stats,omitempty"`
+	State       map[string]EventState `json:"state,omitempty"`
+	Summary     EventSummary          `json:"summary"`
+	Activity    []HistoryEvent        `json:"activity,omitempty"`
+	Rules       []string              `json:"rules,omitempty"`
+	Stale       bool                  `json:"stale,omitempty"`
+	Error       string                `json:"error,omitempty"`
+	ErrorType   ErrorType             `json:"error_type,omitempty"`
+	EventErrors map[string]string     `json:"event_errors,omitempty"`
+
+	// Changes holds what each event source found new, including items such as releases
+	Changes map[string]EventChange `json:"-"`
+}
+
+type RepoActivity struct {
+	Repo         string
+	Issues       []IssueAPIData
+	PullRequests []IssueAPIData
+	Releases     []ReleaseAPIData
+	Stargazers   []StargazerAPIData
+}
+
+type IssueDraft struct {
+	Title string
+	Body  string
+	Label string
 }
@@ -139,6 +139,59 @@ func (mr *MockCacheServiceMockRecorder) Save(cache any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCacheService)(nil).Save), cache)
 }
 
+// MockHistoryService is a mock of HistoryService interface.
+type MockHistoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockHistoryServiceMockRecorder is the mock recorder for MockHistoryService.
+type MockHistoryServiceMockRecorder struct {
+	mock *MockHistoryService
+}
+
+// NewMockHistoryService creates a new mock instance.
+func NewMockHistoryService(ctrl *gomock.Controller) *MockHistoryService {
+	mock := &MockHistoryService{ctrl: ctrl}
+	mock.recorder = &MockHistoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryService) EXPECT() *MockHistoryServiceMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method.
+func (m *MockHistoryService) Load() (*services.HistoryData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load")
+	ret0, _ := ret[0].(*services.HistoryData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockHistoryServiceMockRecorder) Load() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockHistoryService)(nil).Load))
+}
+
+// Save mocks base method.
+func (m *MockHistoryService) Save(history *services.HistoryData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockHistoryServiceMockRecorder) Save(history any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockHistoryService)(nil).Save), history)
+}
+
 // MockGitHubAPIClient is a mock of GitHubAPIClient interface.
 type MockGitHubAPIClient struct {
 	ctrl     *gomock.Controller
@@ -163,6 +216,21 @@ func (m *MockGitHubAPIClient) EXPECT() *MockGitHubAPIClientMockRecorder {
 	return m.recorder
 }
 
+// CountMergedPullRequests mocks base method.
+func (m *MockGitHubAPIClient) CountMergedPullRequests(ctx context.Context, owner, repo, author string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMergedPullRequests", ctx, owner, repo, author)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMergedPullRequests indicates an expected call of CountMergedPullRequests.
+func (mr *MockGitHubAPIClientMockRecorder) CountMergedPullRequests(ctx, owner, repo, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMergedPullRequests", reflect.TypeOf((*MockGitHubAPIClient)(nil).CountMergedPullRequests), ctx, owner, repo, author)
+}
+
 // Get mocks base method.
 func (m *MockGitHubAPIClient) Get(ctx context.Context, path string, response any) error {
 	m.ctrl.T.Helper()
@@ -177,6 +245,141 @@ func (mr *MockGitHubAPIClientMockRecorder) Get(ctx, path, response any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGitHubAPIClient)(nil).Get), ctx, path, response)
 }
 
+// GetAuthenticatedUser mocks base method.
+func (m *MockGitHubAPIClient) GetAuthenticatedUser(ctx context.Context) (*services.UserAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthenticatedUser", ctx)
+	ret0, _ := ret[0].(*services.UserAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthenticatedUser indicates an expected call of GetAuthenticatedUser.
+func (mr *MockGitHubAPIClientMockRecorder) GetAuthenticatedUser(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthenticatedUser", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetAuthenticatedUser), ctx)
+}
+
+// GetCodeScanningAlerts mocks base method.
+func (m *MockGitHubAPIClient) GetCodeScanningAlerts(ctx context.Context, owner, repo string) ([]services.CodeScanningAlertAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCodeScanningAlerts", ctx, owner, repo)
+	ret0, _ := ret[0].([]services.CodeScanningAlertAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCodeScanningAlerts indicates an expected call of GetCodeScanningAlerts.
+func (mr *MockGitHubAPIClientMockRecorder) GetCodeScanningAlerts(ctx, owner, repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeScanningAlerts", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetCodeScanningAlerts), ctx, owner, repo)
+}
+
+// GetContributors mocks base method.
+func (m *MockGitHubAPIClient) GetContributors(ctx context.Context, owner, repo string) ([]services.UserAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContributors", ctx, owner, repo)
+	ret0, _ := ret[0].([]services.UserAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContributors indicates an expected call of GetContributors.
+func (mr *MockGitHubAPIClientMockRecorder) GetContributors(ctx, owner, repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContributors", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetContributors), ctx, owner, repo)
+}
+
+// GetDependabotAlerts mocks base method.
+func (m *MockGitHubAPIClient) GetDependabotAlerts(ctx context.Context, owner, repo string) ([]services.DependabotAlertAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDependabotAlerts", ctx, owner, repo)
+	ret0, _ := ret[0].([]services.DependabotAlertAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDependabotAlerts indicates an expected call of GetDependabotAlerts.
+func (mr *MockGitHubAPIClientMockRecorder) GetDependabotAlerts(ctx, owner, repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDependabotAlerts", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetDependabotAlerts), ctx, owner, repo)
+}
+
+// GetIssueCommentsSince mocks base method.
+func (m *MockGitHubAPIClient) GetIssueCommentsSince(ctx context.Context, owner, repo string, since time.Time) ([]services.IssueCommentAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIssueCommentsSince", ctx, owner, repo, since)
+	ret0, _ := ret[0].([]services.IssueCommentAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIssueCommentsSince indicates an expected call of GetIssueCommentsSince.
+func (mr *MockGitHubAPIClientMockRecorder) GetIssueCommentsSince(ctx, owner, repo, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssueCommentsSince", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetIssueCommentsSince), ctx, owner, repo, since)
+}
+
+// GetIssuesSince mocks base method.
+func (m *MockGitHubAPIClient) GetIssuesSince(ctx context.Context, owner, repo string, since time.Time) ([]services.IssueAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIssuesSince", ctx, owner, repo, since)
+	ret0, _ := ret[0].([]services.IssueAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIssuesSince indicates an expected call of GetIssuesSince.
+func (mr *MockGitHubAPIClientMockRecorder) GetIssuesSince(ctx, owner, repo, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssuesSince", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetIssuesSince), ctx, owner, repo, since)
+}
+
+// GetIssuesUpdatedSince mocks base method.
+func (m *MockGitHubAPIClient) GetIssuesUpdatedSince(ctx context.Context, owner, repo string, since time.Time) ([]services.IssueAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIssuesUpdatedSince", ctx, owner, repo, since)
+	ret0, _ := ret[0].([]services.IssueAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIssuesUpdatedSince indicates an expected call of GetIssuesUpdatedSince.
+func (mr *MockGitHubAPIClientMockRecorder) GetIssuesUpdatedSince(ctx, owner, repo, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssuesUpdatedSince", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetIssuesUpdatedSince), ctx, owner, repo, since)
+}
+
+// GetMergedPullRequestsSince mocks base method.
+func (m *MockGitHubAPIClient) GetMergedPullRequestsSince(ctx context.Context, owner, repo string, since time.Time) ([]services.PullRequestAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMergedPullRequestsSince", ctx, owner, repo, since)
+	ret0, _ := ret[0].([]services.PullRequestAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMergedPullRequestsSince indicates an expected call of GetMergedPullRequestsSince.
+func (mr *MockGitHubAPIClientMockRecorder) GetMergedPullRequestsSince(ctx, owner, repo, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMergedPullRequestsSince", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetMergedPullRequestsSince), ctx, owner, repo, since)
+}
+
+// GetNotifications mocks base method.
+func (m *MockGitHubAPIClient) GetNotifications(ctx context.Context) ([]services.NotificationAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx)
+	ret0, _ := ret[0].([]services.NotificationAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockGitHubAPIClientMockRecorder) GetNotifications(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetNotifications), ctx)
+}
+
 // GetPullRequests mocks base method.
 func (m *MockGitHubAPIClient) GetPullRequests(ctx context.Context, owner, repo string) ([]services.PullRequestAPIData, error) {
 	m.ctrl.T.Helper()
@@ -192,6 +395,21 @@ func (mr *MockGitHubAPIClientMockRecorder) GetPullRequests(ctx, owner, repo any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequests", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetPullRequests), ctx, owner, repo)
 }
 
+// GetReleases mocks base method.
+func (m *MockGitHubAPIClient) GetReleases(ctx context.Context, owner, repo string) ([]services.ReleaseAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReleases", ctx, owner, repo)
+	ret0, _ := ret[0].([]services.ReleaseAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReleases indicates an expected call of GetReleases.
+func (mr *MockGitHubAPIClientMockRecorder) GetReleases(ctx, owner, repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReleases", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetReleases), ctx, owner, repo)
+}
+
 // GetRepoData mocks base method.
 func (m *MockGitHubAPIClient) GetRepoData(ctx context.Context, owner, repo string) (*services.RepoAPIData, error) {
 	m.ctrl.T.Helper()
@@ -207,6 +425,166 @@ func (mr *MockGitHubAPIClientMockRecorder) GetRepoData(ctx, owner, repo any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoData", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetRepoData), ctx, owner, repo)
 }
 
+// GetSecretScanningAlerts mocks base method.
+func (m *MockGitHubAPIClient) GetSecretScanningAlerts(ctx context.Context, owner, repo string) ([]services.SecretScanningAlertAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretScanningAlerts", ctx, owner, repo)
+	ret0, _ := ret[0].([]services.SecretScanningAlertAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretScanningAlerts indicates an expected call of GetSecretScanningAlerts.
+func (mr *MockGitHubAPIClientMockRecorder) GetSecretScanningAlerts(ctx, owner, repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretScanningAlerts", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetSecretScanningAlerts), ctx, owner, repo)
+}
+
+// GetStargazersSince mocks base method.
+func (m *MockGitHubAPIClient) GetStargazersSince(ctx context.Context, owner, repo string, since time.Time) ([]services.StargazerAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStargazersSince", ctx, owner, repo, since)
+	ret0, _ := ret[0].([]services.StargazerAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStargazersSince indicates an expected call of GetStargazersSince.
+func (mr *MockGitHubAPIClientMockRecorder) GetStargazersSince(ctx, owner, repo, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStargazersSince", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetStargazersSince), ctx, owner, repo, since)
+}
+
+// GetTags mocks base method.
+func (m *MockGitHubAPIClient) GetTags(ctx context.Context, owner, repo string) ([]services.TagAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx, owner, repo)
+	ret0, _ := ret[0].([]services.TagAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockGitHubAPIClientMockRecorder) GetTags(ctx, owner, repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetTags), ctx, owner, repo)
+}
+
+// GetTraffic mocks base method.
+func (m *MockGitHubAPIClient) GetTraffic(ctx context.Context, owner, repo string) (*services.TrafficAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTraffic", ctx, owner, repo)
+	ret0, _ := ret[0].(*services.TrafficAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTraffic indicates an expected call of GetTraffic.
+func (mr *MockGitHubAPIClientMockRecorder) GetTraffic(ctx, owner, repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTraffic", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetTraffic), ctx, owner, repo)
+}
+
+// GetUser mocks base method.
+func (m *MockGitHubAPIClient) GetUser(ctx context.Context, login string) (*services.UserAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, login)
+	ret0, _ := ret[0].(*services.UserAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockGitHubAPIClientMockRecorder) GetUser(ctx, login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetUser), ctx, login)
+}
+
+// GetWorkflowRuns mocks base method.
+func (m *MockGitHubAPIClient) GetWorkflowRuns(ctx context.Context, owner, repo, branch string) ([]services.WorkflowRunAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkflowRuns", ctx, owner, repo, branch)
+	ret0, _ := ret[0].([]services.WorkflowRunAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkflowRuns indicates an expected call of GetWorkflowRuns.
+func (mr *MockGitHubAPIClientMockRecorder) GetWorkflowRuns(ctx, owner, repo, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkflowRuns", reflect.TypeOf((*MockGitHubAPIClient)(nil).GetWorkflowRuns), ctx, owner, repo, branch)
+}
+
+// GraphQL mocks base method.
+func (m *MockGitHubAPIClient) GraphQL(ctx context.Context, query string, variables map[string]any, response any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GraphQL", ctx, query, variables, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GraphQL indicates an expected call of GraphQL.
+func (mr *MockGitHubAPIClientMockRecorder) GraphQL(ctx, query, variables, response any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GraphQL", reflect.TypeOf((*MockGitHubAPIClient)(nil).GraphQL), ctx, query, variables, response)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockGitHubAPIClient) MarkNotificationRead(ctx context.Context, threadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, threadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockGitHubAPIClientMockRecorder) MarkNotificationRead(ctx, threadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockGitHubAPIClient)(nil).MarkNotificationRead), ctx, threadID)
+}
+
+// Patch mocks base method.
+func (m *MockGitHubAPIClient) Patch(ctx context.Context, path string, body, response any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, path, body, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockGitHubAPIClientMockRecorder) Patch(ctx, path, body, response any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockGitHubAPIClient)(nil).Patch), ctx, path, body, response)
+}
+
+// Post mocks base method.
+func (m *MockGitHubAPIClient) Post(ctx context.Context, path string, body, response any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", ctx, path, body, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockGitHubAPIClientMockRecorder) Post(ctx, path, body, response any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockGitHubAPIClient)(nil).Post), ctx, path, body, response)
+}
+
+// RateLimit mocks base method.
+func (m *MockGitHubAPIClient) RateLimit() services.RateLimitInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLimit")
+	ret0, _ := ret[0].(services.RateLimitInfo)
+	return ret0
+}
+
+// RateLimit indicates an expected call of RateLimit.
+func (mr *MockGitHubAPIClientMockRecorder) RateLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimit", reflect.TypeOf((*MockGitHubAPIClient)(nil).RateLimit))
+}
+
 // MockGitHubService is a mock of GitHubService interface.
 type MockGitHubService struct {
 	ctrl     *gomock.Controller
@@ -348,6 +726,490 @@ func (mr *MockBatchGitHubServiceMockRecorder) SetTimeout(timeout any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeout", reflect.TypeOf((*MockBatchGitHubService)(nil).SetTimeout), timeout)
 }
 
+// MockActivityGitHubService is a mock of ActivityGitHubService interface.
+type MockActivityGitHubService struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityGitHubServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockActivityGitHubServiceMockRecorder is the mock recorder for MockActivityGitHubService.
+type MockActivityGitHubServiceMockRecorder struct {
+	mock *MockActivityGitHubService
+}
+
+// NewMockActivityGitHubService creates a new mock instance.
+func NewMockActivityGitHubService(ctrl *gomock.Controller) *MockActivityGitHubService {
+	mock := &MockActivityGitHubService{ctrl: ctrl}
+	mock.recorder = &MockActivityGitHubServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityGitHubService) EXPECT() *MockActivityGitHubServiceMockRecorder {
+	return m.recorder
+}
+
+// GetNewIssues mocks base method.
+func (m *MockActivityGitHubService) GetNewIssues(owner, repo string, since time.Time) ([]services.IssueAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNewIssues", owner, repo, since)
+	ret0, _ := ret[0].([]services.IssueAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNewIssues indicates an expected call of GetNewIssues.
+func (mr *MockActivityGitHubServiceMockRecorder) GetNewIssues(owner, repo, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewIssues", reflect.TypeOf((*MockActivityGitHubService)(nil).GetNewIssues), owner, repo, since)
+}
+
+// GetRepoActivity mocks base method.
+func (m *MockActivityGitHubService) GetRepoActivity(owner, repo string, since time.Time) (*services.RepoActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoActivity", owner, repo, since)
+	ret0, _ := ret[0].(*services.RepoActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepoActivity indicates an expected call of GetRepoActivity.
+func (mr *MockActivityGitHubServiceMockRecorder) GetRepoActivity(owner, repo, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoActivity", reflect.TypeOf((*MockActivityGitHubService)(nil).GetRepoActivity), owner, repo, since)
+}
+
+// GetRepoStats mocks base method.
+func (m *MockActivityGitHubService) GetRepoStats(owner, repo string) (*services.RepoStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoStats", owner, repo)
+	ret0, _ := ret[0].(*services.RepoStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepoStats indicates an expected call of GetRepoStats.
+func (mr *MockActivityGitHubServiceMockRecorder) GetRepoStats(owner, repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoStats", reflect.TypeOf((*MockActivityGitHubService)(nil).GetRepoStats), owner, repo)
+}
+
+// SetMaxConcurrent mocks base method.
+func (m *MockActivityGitHubService) SetMaxConcurrent(maxConcurrent int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMaxConcurrent", maxConcurrent)
+}
+
+// SetMaxConcurrent indicates an expected call of SetMaxConcurrent.
+func (mr *MockActivityGitHubServiceMockRecorder) SetMaxConcurrent(maxConcurrent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxConcurrent", reflect.TypeOf((*MockActivityGitHubService)(nil).SetMaxConcurrent), maxConcurrent)
+}
+
+// SetTimeout mocks base method.
+func (m *MockActivityGitHubService) SetTimeout(timeout time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTimeout", timeout)
+}
+
+// SetTimeout indicates an expected call of SetTimeout.
+func (mr *MockActivityGitHubServiceMockRecorder) SetTimeout(timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeout", reflect.TypeOf((*MockActivityGitHubService)(nil).SetTimeout), timeout)
+}
+
+// MockEventGitHubService is a mock of EventGitHubService interface.
+type MockEventGitHubService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventGitHubServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockEventGitHubServiceMockRecorder is the mock recorder for MockEventGitHubService.
+type MockEventGitHubServiceMockRecorder struct {
+	mock *MockEventGitHubService
+}
+
+// NewMockEventGitHubService creates a new mock instance.
+func NewMockEventGitHubService(ctrl *gomock.Controller) *MockEventGitHubService {
+	mock := &MockEventGitHubService{ctrl: ctrl}
+	mock.recorder = &MockEventGitHubServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventGitHubService) EXPECT() *MockEventGitHubServiceMockRecorder {
+	return m.recorder
+}
+
+// FetchEventStates mocks base method.
+func (m *MockEventGitHubService) FetchEventStates(stats *services.RepoStats, events []string, previous services.RepoState) (map[string]services.EventState, map[string]error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchEventStates", stats, events, previous)
+	ret0, _ := ret[0].(map[string]services.EventState)
+	ret1, _ := ret[1].(map[string]error)
+	return ret0, ret1
+}
+
+// FetchEventStates indicates an expected call of FetchEventStates.
+func (mr *MockEventGitHubServiceMockRecorder) FetchEventStates(stats, events, previous any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEventStates", reflect.TypeOf((*MockEventGitHubService)(nil).FetchEventStates), stats, events, previous)
+}
+
+// GetRepoStats mocks base method.
+func (m *MockEventGitHubService) GetRepoStats(owner, repo string) (*services.RepoStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoStats", owner, repo)
+	ret0, _ := ret[0].(*services.RepoStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepoStats indicates an expected call of GetRepoStats.
+func (mr *MockEventGitHubServiceMockRecorder) GetRepoStats(owner, repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoStats", reflect.TypeOf((*MockEventGitHubService)(nil).GetRepoStats), owner, repo)
+}
+
+// SetMaxConcurrent mocks base method.
+func (m *MockEventGitHubService) SetMaxConcurrent(maxConcurrent int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMaxConcurrent", maxConcurrent)
+}
+
+// SetMaxConcurrent indicates an expected call of SetMaxConcurrent.
+func (mr *MockEventGitHubServiceMockRecorder) SetMaxConcurrent(maxConcurrent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxConcurrent", reflect.TypeOf((*MockEventGitHubService)(nil).SetMaxConcurrent), maxConcurrent)
+}
+
+// SetTimeout mocks base method.
+func (m *MockEventGitHubService) SetTimeout(timeout time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTimeout", timeout)
+}
+
+// SetTimeout indicates an expected call of SetTimeout.
+func (mr *MockEventGitHubServiceMockRecorder) SetTimeout(timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeout", reflect.TypeOf((*MockEventGitHubService)(nil).SetTimeout), timeout)
+}
+
+// MockBatchEventGitHubService is a mock of BatchEventGitHubService interface.
+type MockBatchEventGitHubService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchEventGitHubServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchEventGitHubServiceMockRecorder is the mock recorder for MockBatchEventGitHubService.
+type MockBatchEventGitHubServiceMockRecorder struct {
+	mock *MockBatchEventGitHubService
+}
+
+// NewMockBatchEventGitHubService creates a new mock instance.
+func NewMockBatchEventGitHubService(ctrl *gomock.Controller) *MockBatchEventGitHubService {
+	mock := &MockBatchEventGitHubService{ctrl: ctrl}
+	mock.recorder = &MockBatchEventGitHubServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchEventGitHubService) EXPECT() *MockBatchEventGitHubServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRepoEventsBatch mocks base method.
+func (m *MockBatchEventGitHubService) GetRepoEventsBatch(requests []services.RepoEventsRequest) []services.RepoEventsResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoEventsBatch", requests)
+	ret0, _ := ret[0].([]services.RepoEventsResult)
+	return ret0
+}
+
+// GetRepoEventsBatch indicates an expected call of GetRepoEventsBatch.
+func (mr *MockBatchEventGitHubServiceMockRecorder) GetRepoEventsBatch(requests any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoEventsBatch", reflect.TypeOf((*MockBatchEventGitHubService)(nil).GetRepoEventsBatch), requests)
+}
+
+// GetRepoStats mocks base method.
+func (m *MockBatchEventGitHubService) GetRepoStats(owner, repo string) (*services.RepoStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoStats", owner, repo)
+	ret0, _ := ret[0].(*services.RepoStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepoStats indicates an expected call of GetRepoStats.
+func (mr *MockBatchEventGitHubServiceMockRecorder) GetRepoStats(owner, repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoStats", reflect.TypeOf((*MockBatchEventGitHubService)(nil).GetRepoStats), owner, repo)
+}
+
+// GetRepoStatsBatch mocks base method.
+func (m *MockBatchEventGitHubService) GetRepoStatsBatch(repos []string) ([]*services.RepoStats, []error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoStatsBatch", repos)
+	ret0, _ := ret[0].([]*services.RepoStats)
+	ret1, _ := ret[1].([]error)
+	return ret0, ret1
+}
+
+// GetRepoStatsBatch indicates an expected call of GetRepoStatsBatch.
+func (mr *MockBatchEventGitHubServiceMockRecorder) GetRepoStatsBatch(repos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoStatsBatch", reflect.TypeOf((*MockBatchEventGitHubService)(nil).GetRepoStatsBatch), repos)
+}
+
+// SetMaxConcurrent mocks base method.
+func (m *MockBatchEventGitHubService) SetMaxConcurrent(maxConcurrent int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMaxConcurrent", maxConcurrent)
+}
+
+// SetMaxConcurrent indicates an expected call of SetMaxConcurrent.
+func (mr *MockBatchEventGitHubServiceMockRecorder) SetMaxConcurrent(maxConcurrent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxConcurrent", reflect.TypeOf((*MockBatchEventGitHubService)(nil).SetMaxConcurrent), maxConcurrent)
+}
+
+// SetTimeout mocks base method.
+func (m *MockBatchEventGitHubService) SetTimeout(timeout time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTimeout", timeout)
+}
+
+// SetTimeout indicates an expected call of SetTimeout.
+func (mr *MockBatchEventGitHubServiceMockRecorder) SetTimeout(timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeout", reflect.TypeOf((*MockBatchEventGitHubService)(nil).SetTimeout), timeout)
+}
+
+// MockInboxGitHubService is a mock of InboxGitHubService interface.
+type MockInboxGitHubService struct {
+	ctrl     *gomock.Controller
+	recorder *MockInboxGitHubServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockInboxGitHubServiceMockRecorder is the mock recorder for MockInboxGitHubService.
+type MockInboxGitHubServiceMockRecorder struct {
+	mock *MockInboxGitHubService
+}
+
+// NewMockInboxGitHubService creates a new mock instance.
+func NewMockInboxGitHubService(ctrl *gomock.Controller) *MockInboxGitHubService {
+	mock := &MockInboxGitHubService{ctrl: ctrl}
+	mock.recorder = &MockInboxGitHubServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInboxGitHubService) EXPECT() *MockInboxGitHubServiceMockRecorder {
+	return m.recorder
+}
+
+// GetNotifications mocks base method.
+func (m *MockInboxGitHubService) GetNotifications(repos []string) ([]services.NotificationAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", repos)
+	ret0, _ := ret[0].([]services.NotificationAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockInboxGitHubServiceMockRecorder) GetNotifications(repos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockInboxGitHubService)(nil).GetNotifications), repos)
+}
+
+// GetRepoStats mocks base method.
+func (m *MockInboxGitHubService) GetRepoStats(owner, repo string) (*services.RepoStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoStats", owner, repo)
+	ret0, _ := ret[0].(*services.RepoStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepoStats indicates an expected call of GetRepoStats.
+func (mr *MockInboxGitHubServiceMockRecorder) GetRepoStats(owner, repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoStats", reflect.TypeOf((*MockInboxGitHubService)(nil).GetRepoStats), owner, repo)
+}
+
+// MarkNotificationsRead mocks base method.
+func (m *MockInboxGitHubService) MarkNotificationsRead(threadIDs []string) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationsRead", threadIDs)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// MarkNotificationsRead indicates an expected call of MarkNotificationsRead.
+func (mr *MockInboxGitHubServiceMockRecorder) MarkNotificationsRead(threadIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsRead", reflect.TypeOf((*MockInboxGitHubService)(nil).MarkNotificationsRead), threadIDs)
+}
+
+// SetMaxConcurrent mocks base method.
+func (m *MockInboxGitHubService) SetMaxConcurrent(maxConcurrent int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMaxConcurrent", maxConcurrent)
+}
+
+// SetMaxConcurrent indicates an expected call of SetMaxConcurrent.
+func (mr *MockInboxGitHubServiceMockRecorder) SetMaxConcurrent(maxConcurrent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxConcurrent", reflect.TypeOf((*MockInboxGitHubService)(nil).SetMaxConcurrent), maxConcurrent)
+}
+
+// SetTimeout mocks base method.
+func (m *MockInboxGitHubService) SetTimeout(timeout time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTimeout", timeout)
+}
+
+// SetTimeout indicates an expected call of SetTimeout.
+func (mr *MockInboxGitHubServiceMockRecorder) SetTimeout(timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeout", reflect.TypeOf((*MockInboxGitHubService)(nil).SetTimeout), timeout)
+}
+
+// MockRateLimitReporter is a mock of RateLimitReporter interface.
+type MockRateLimitReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockRateLimitReporterMockRecorder is the mock recorder for MockRateLimitReporter.
+type MockRateLimitReporterMockRecorder struct {
+	mock *MockRateLimitReporter
+}
+
+// NewMockRateLimitReporter creates a new mock instance.
+func NewMockRateLimitReporter(ctrl *gomock.Controller) *MockRateLimitReporter {
+	mock := &MockRateLimitReporter{ctrl: ctrl}
+	mock.recorder = &MockRateLimitReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitReporter) EXPECT() *MockRateLimitReporterMockRecorder {
+	return m.recorder
+}
+
+// RateLimit mocks base method.
+func (m *MockRateLimitReporter) RateLimit() services.RateLimitInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLimit")
+	ret0, _ := ret[0].(services.RateLimitInfo)
+	return ret0
+}
+
+// RateLimit indicates an expected call of RateLimit.
+func (mr *MockRateLimitReporterMockRecorder) RateLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimit", reflect.TypeOf((*MockRateLimitReporter)(nil).RateLimit))
+}
+
+// MockIssuePublisher is a mock of IssuePublisher interface.
+type MockIssuePublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssuePublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockIssuePublisherMockRecorder is the mock recorder for MockIssuePublisher.
+type MockIssuePublisherMockRecorder struct {
+	mock *MockIssuePublisher
+}
+
+// NewMockIssuePublisher creates a new mock instance.
+func NewMockIssuePublisher(ctrl *gomock.Controller) *MockIssuePublisher {
+	mock := &MockIssuePublisher{ctrl: ctrl}
+	mock.recorder = &MockIssuePublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssuePublisher) EXPECT() *MockIssuePublisherMockRecorder {
+	return m.recorder
+}
+
+// UpsertPinnedIssue mocks base method.
+func (m *MockIssuePublisher) UpsertPinnedIssue(owner, repo string, draft services.IssueDraft) (*services.IssueAPIData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPinnedIssue", owner, repo, draft)
+	ret0, _ := ret[0].(*services.IssueAPIData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertPinnedIssue indicates an expected call of UpsertPinnedIssue.
+func (mr *MockIssuePublisherMockRecorder) UpsertPinnedIssue(owner, repo, draft any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPinnedIssue", reflect.TypeOf((*MockIssuePublisher)(nil).UpsertPinnedIssue), owner, repo, draft)
+}
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockNotifier) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockNotifierMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockNotifier)(nil).Name))
+}
+
+// Notify mocks base method.
+func (m *MockNotifier) Notify(ctx context.Context, run *services.RunResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotifierMockRecorder) Notify(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotifier)(nil).Notify), ctx, run)
+}
+
 // MockOutput is a mock of Output interface.
 type MockOutput struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,112 @@
+package services
+
+import "fmt"
+
+// NewNotifiers builds the notifiers declared in the config
+func NewNotifiers(config *Config) ([]Notifier, error) {
+	var notifiers []Notifier
+
+	for i, webhookConfig := range config.Notifiers.Webhooks {
+		if webhookConfig.Name == "" {
+			webhookConfig.Name = fmt.Sprintf("webhook-%d", i+1)
+		}
+		notifier, err := NewWebhookNotifier(webhookConfig)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, notifier)
+	}
+
+	for i, slackConfig := range config.Notifiers.Slack {
+		if slackConfig.Name == "" {
+			slackConfig.Name = fmt.Sprintf("slack-%d", i+1)
+		}
+		notifiers = append(notifiers, NewSlackNotifier(slackConfig, config))
+	}
+
+	for i, emailConfig := range config.Notifiers.Email {
+		if emailConfig.Name == "" {
+			emailConfig.Name = fmt.Sprintf("email-%d", i+1)
+		}
+		notifier, err := NewEmailNotifier(emailConfig)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, notifier)
+	}
+
+	if config.Hooks.hasHooks() {
+		runner, err := NewHookRunner(config.Hooks)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, runner)
+	}
+
+	return notifiers, nil
+}
+
+// HasChanges reports whether any repository in the run has new activity
+func (r *RunResult) HasChanges() bool {
+	for _, repo := range r.Repos {
+		if repo.Summary.HasChanges {
+			return true
+		}
+	}
+	return false
+}
+
+// Changed returns the repositories with new activity
+func (r *RunResult) Changed() []RepoRunResult {
+	var changed []RepoRunResult
+	for _, repo := range r.Repos {
+		if repo.Summary.HasChanges {
+			changed = append(changed, repo)
+		}
+	}
+	return changed
+}
+
+// Failed returns the repositories whose stats could not be fetched
+func (r *RunResult) Failed() []RepoRunResult {
+	var failed []RepoRunResult
+	for _, repo := range r.Repos {
+		if repo.Error != "" {
+			failed = append(failed, repo)
+		}
+	}
+	return failed
+}
+
+// Delta returns the change for a watched event name such as "stars"
+func (r RepoRunResult) Delta(event string) int {
+	return r.Summary.Delta(event)
+}
+
+// ChangeLines describes each watched event that changed, e.g. "⭐ +3 stars (120 total)"
+func (r RepoRunResult) ChangeLines() []string {
+	var lines []string
+	for _, event := range r.Events {
+		if change := r.Change(event); change.Count > 0 {
+			lines = append(lines, DescribeChange(event, change, r.CurrentState(event)))
+		}
+	}
+	return lines
+}
+
+// Change returns what an event source found new, falling back to the summary's count
+func (r RepoRunResult) Change(event string) EventChange {
+	if change, ok := r.Changes[event]; ok {
+		return change
+	}
+	return EventChange{Count: r.Delta(event)}
+}
+
+// CurrentState returns the fetched state for an event, or nil when it is unknown
+func (r RepoRunResult) CurrentState(event string) *EventState {
+	state, ok := r.State[event]
+	if !ok {
+		return nil
+	}
+	return &state
+}
@@ -0,0 +1,505 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// RuleEngine decides which notifiers hear about which repositories.
+// Rules are evaluated against every repository with new activity; a repository is
+// delivered to a rule's notifiers when its condition holds and the rule has not
+// already fired for that repository within its throttle window.
+type RuleEngine struct {
+	rules  []compiledRule
+	config *Config
+}
+
+type compiledRule struct {
+	RuleConfig
+	condition ruleExpr
+	throttle  time.Duration
+}
+
+// ruleContext holds the values a rule condition can refer to
+type ruleContext struct {
+	Repo   RepoRunResult
+	Time   time.Time
+	Config *Config
+}
+
+func NewRuleEngine(config *Config) (*RuleEngine, error) {
+	engine := &RuleEngine{config: config}
+
+	for i, rule := range config.Rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+
+		compiled := compiledRule{RuleConfig: rule}
+
+		condition, err := parseRuleCondition(rule.When)
+		if err != nil {
+			return nil, NewConfigError(fmt.Sprintf("rule %s: invalid condition", rule.Name), err)
+		}
+		compiled.condition = condition
+
+		if rule.Throttle != "" {
+			compiled.throttle, err = ParseDuration(rule.Throttle)
+			if err != nil {
+				return nil, NewConfigError(fmt.Sprintf("rule %s: invalid throttle", rule.Name), err)
+			}
+		}
+
+		engine.rules = append(engine.rules, compiled)
+	}
+
+	return engine, nil
+}
+
+// Route splits a run into one result per notifier name. Failed repositories are
+// delivered to every notifier; repositories with changes only to the notifiers of
+// the rules they match. Rules still inside their throttle window are skipped; record
+// the ones that fired with RecordDelivery once a notifier has delivered them.
+func (e *RuleEngine) Route(run *RunResult, notifiers []string, throttle map[string]time.Time) map[string]*RunResult {
+	routed := make(map[string]*RunResult, len(notifiers))
+	for _, name := range notifiers {
+		routed[name] = &RunResult{CheckedAt: run.CheckedAt}
+	}
+
+	for _, repo := range run.Repos {
+		if repo.Error != "" {
+			for _, name := range notifiers {
+				routed[name].Repos = append(routed[name].Repos, repo)
+			}
+			continue
+		}
+		if !repo.Summary.HasChanges {
+			continue
+		}
+
+		targets := map[string][]string{}
+		for _, rule := range e.rules {
+			if !rule.condition.eval(ruleContext{Repo: repo, Time: run.CheckedAt, Config: e.config}) {
+				continue
+			}
+
+			key := rule.Name + "|" + repo.Repo
+			if last, ok := throttle[key]; ok && rule.throttle > 0 && run.CheckedAt.Sub(last) < rule.throttle {
+				continue
+			}
+
+			for _, name := range notifiers {
+				if len(rule.Notify) == 0 || slices.Contains(rule.Notify, name) {
+					targets[name] = append(targets[name], rule.Name)
+				}
+			}
+		}
+
+		for _, name := range notifiers {
+			if rules, ok := targets[name]; ok {
+				matched := repo
+				matched.Rules = rules
+				routed[name].Repos = append(routed[name].Repos, matched)
+			}
+		}
+	}
+
+	e.pruneThrottle(run.CheckedAt, throttle)
+
+	return routed
+}
+
+// RecordDelivery starts the throttle window of every rule in a routed result, keyed
+// by rule and repo. Call it only after the result has been delivered, so a failed
+// delivery is retried on the next run.
+func (e *RuleEngine) RecordDelivery(routed *RunResult, throttle map[string]time.Time) {
+	if throttle == nil {
+		return
+	}
+	for _, repo := range routed.Repos {
+		for _, name := range repo.Rules {
+			throttle[name+"|"+repo.Repo] = routed.CheckedAt
+		}
+	}
+}
+
+// UnknownNotifiers lists notifier names referenced by rules that are not configured
+func (e *RuleEngine) UnknownNotifiers(notifiers []string) []string {
+	var unknown []string
+	for _, rule := range e.rules {
+		for _, name := range rule.Notify {
+			if !slices.Contains(notifiers, name) && !slices.Contains(unknown, name) {
+				unknown = append(unknown, name)
+			}
+		}
+	}
+	return unknown
+}
+
+// pruneThrottle forgets entries whose window has passed or whose rule no longer exists
+func (e *RuleEngine) pruneThrottle(now time.Time, throttle map[string]time.Time) {
+	windows := map[string]time.Duration{}
+	for _, rule := range e.rules {
+		windows[rule.Name] = rule.throttle
+	}
+
+	for key, last := range throttle {
+		name, _, _ := strings.Cut(key, "|")
+		window, ok := windows[name]
+		if !ok || now.Sub(last) >= window {
+			delete(throttle, key)
+		}
+	}
+}
+
+// ruleExpr is a parsed rule condition
+type ruleExpr interface {
+	eval(ctx ruleContext) bool
+}
+
+type ruleAnd []ruleExpr
+
+func (r ruleAnd) eval(ctx ruleContext) bool {
+	for _, expr := range r {
+		if !expr.eval(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+type ruleOr []ruleExpr
+
+func (r ruleOr) eval(ctx ruleContext) bool {
+	for _, expr := range r {
+		if expr.eval(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+type ruleNot struct {
+	expr ruleExpr
+}
+
+func (r ruleNot) eval(ctx ruleContext) bool {
+	return !r.expr.eval(ctx)
+}
+
+type ruleCompare struct {
+	field string
+	op    string
+	value int
+}
+
+func (r ruleCompare) eval(ctx ruleContext) bool {
+	v := ruleFieldValue(r.field, ctx)
+	switch r.op {
+	case ">":
+		return v > r.value
+	case ">=":
+		return v >= r.value
+	case "<":
+		return v < r.value
+	case "<=":
+		return v <= r.value
+	case "==":
+		return v == r.value
+	case "!=":
+		return v != r.value
+	}
+	return false
+}
+
+// ruleBetween is inclusive and wraps around, so "hour between 22 and 6" spans midnight
+type ruleBetween struct {
+	field string
+	low   int
+	high  int
+}
+
+func (r ruleBetween) eval(ctx ruleContext) bool {
+	v := ruleFieldValue(r.field, ctx)
+	if r.low <= r.high {
+		return v >= r.low && v <= r.high
+	}
+	return v >= r.low || v <= r.high
+}
+
+// ruleRepoMatch matches the repository against "group:<name>" or a glob pattern
+type ruleRepoMatch struct {
+	target string
+	negate bool
+}
+
+func (r ruleRepoMatch) eval(ctx ruleContext) bool {
+	var matched bool
+	if group, ok := strings.CutPrefix(r.target, "group:"); ok {
+		matched = ctx.Config != nil && ctx.Config.InGroup(ctx.Repo.Repo, group)
+	} else {
+		matched = MatchRepoPatterns([]string{r.target}, ctx.Repo.Repo)
+	}
+	return matched != r.negate
+}
+
+type ruleAlways struct{}
+
+func (ruleAlways) eval(ruleContext) bool {
+	return true
+}
+
+// ruleFields are the numeric values available in conditions besides "<event>" and
+// "<event>_delta" for every registered event source
+var ruleFields = map[string]func(ctx ruleContext) int{
+	"issues_open":        func(ctx ruleContext) int { return ctx.Repo.State["issues"].Count },
+	"pull_requests_open": func(ctx ruleContext) int { return ctx.Repo.State["pull_requests"].Count },
+	"hour":               func(ctx ruleContext) int { return ctx.Time.Hour() },
+	"weekday":            func(ctx ruleContext) int { return int(ctx.Time.Weekday()) },
+}
+
+// lookupRuleField resolves a field name to the function reading its value
+func lookupRuleField(field string) (func(ctx ruleContext) int, bool) {
+	if fn, ok := ruleFields[field]; ok {
+		return fn, true
+	}
+	if event, ok := strings.CutSuffix(field, "_delta"); ok {
+		if _, registered := LookupEventSource(event); registered {
+			return func(ctx ruleContext) int { return ctx.Repo.Delta(event) }, true
+		}
+	}
+	if _, registered := LookupEventSource(field); registered {
+		return func(ctx ruleContext) int { return ctx.Repo.State[field].Count }, true
+	}
+	return nil, false
+}
+
+func ruleFieldValue(field string, ctx ruleContext) int {
+	fn, _ := lookupRuleField(field)
+	return fn(ctx)
+}
+
+// parseRuleCondition parses expressions such as
+// "stars_delta >= 10 and (repo in group:core or hour between 9 and 18)".
+// An empty condition matches every repository with changes.
+func parseRuleCondition(condition string) (ruleExpr, error) {
+	tokens, err := tokenizeRule(condition)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return ruleAlways{}, nil
+	}
+
+	p := &ruleParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q", p.peek())
+	}
+	return expr, nil
+}
+
+func tokenizeRule(s string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		case strings.ContainsRune("<>=!&|", c):
+			j := i + 1
+			for j < len(s) && strings.ContainsRune("<>=&|", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(s[i+1:], s[i])
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, s[i+1:i+1+end])
+			i += end + 2
+		default:
+			j := i
+			for j < len(s) && !unicode.IsSpace(rune(s[j])) && !strings.ContainsRune("()<>=!&|", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type ruleParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *ruleParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *ruleParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *ruleParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *ruleParser) accept(tokens ...string) bool {
+	if slices.Contains(tokens, strings.ToLower(p.peek())) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *ruleParser) parseOr() (ruleExpr, error) {
+	expr, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	exprs := ruleOr{expr}
+	for p.accept("or", "||") {
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return exprs, nil
+}
+
+func (p *ruleParser) parseAnd() (ruleExpr, error) {
+	expr, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	exprs := ruleAnd{expr}
+	for p.accept("and", "&&") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return exprs, nil
+}
+
+func (p *ruleParser) parseUnary() (ruleExpr, error) {
+	if p.accept("not", "!") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return ruleNot{expr: expr}, nil
+	}
+
+	if p.accept("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return expr, nil
+	}
+
+	return p.parseCondition()
+}
+
+func (p *ruleParser) parseCondition() (ruleExpr, error) {
+	field := strings.ToLower(p.next())
+	if field == "" {
+		return nil, fmt.Errorf("unexpected end of condition")
+	}
+
+	if field == "repo" {
+		switch {
+		case p.accept("in", "==", "matches"):
+			return p.parseRepoTarget(false)
+		case p.accept("!="):
+			return p.parseRepoTarget(true)
+		case p.accept("not"):
+			if !p.accept("in") {
+				return nil, fmt.Errorf("expected \"in\" after \"repo not\"")
+			}
+			return p.parseRepoTarget(true)
+		}
+		return nil, fmt.Errorf("expected \"in\", \"==\" or \"!=\" after \"repo\"")
+	}
+
+	if _, ok := lookupRuleField(field); !ok {
+		return nil, fmt.Errorf("unknown field %q", field)
+	}
+
+	if p.accept("between") {
+		low, err := p.parseNumber()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept("and") {
+			return nil, fmt.Errorf("expected \"and\" in \"%s between\"", field)
+		}
+		high, err := p.parseNumber()
+		if err != nil {
+			return nil, err
+		}
+		return ruleBetween{field: field, low: low, high: high}, nil
+	}
+
+	op := p.next()
+	switch op {
+	case ">", ">=", "<", "<=", "==", "!=":
+	default:
+		return nil, fmt.Errorf("expected comparison after %q, got %q", field, op)
+	}
+
+	value, err := p.parseNumber()
+	if err != nil {
+		return nil, err
+	}
+	return ruleCompare{field: field, op: op, value: value}, nil
+}
+
+func (p *ruleParser) parseRepoTarget(negate bool) (ruleExpr, error) {
+	target := p.next()
+	if target == "" {
+		return nil, fmt.Errorf("expected repository pattern or group")
+	}
+	return ruleRepoMatch{target: target, negate: negate}, nil
+}
+
+func (p *ruleParser) parseNumber() (int, error) {
+	token := p.next()
+	value, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("expected number, got %q", token)
+	}
+	return value, nil
+}
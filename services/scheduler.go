@@ -0,0 +1,90 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// activeWindow is how recently a repository must have changed to be polled more often
+	activeWindow = 24 * time.Hour
+
+	// minAdaptiveInterval stops active repositories from being polled more than this often
+	minAdaptiveInterval = time.Minute
+
+	// scheduleSlack treats repositories that are almost due as due, so timer drift does not skip a cycle
+	scheduleSlack = 30 * time.Second
+)
+
+// RepoSchedule describes whether a repository should be fetched on this run
+type RepoSchedule struct {
+	Repo RepoConfig
+	Due  bool
+
+	// Interval is the effective interval after adapting to recent activity; zero means every run
+	Interval    time.Duration
+	LastFetched time.Time
+	NextDue     time.Time
+}
+
+// ScheduleRepos decides which repositories are due. Repositories without an interval,
+// or never fetched, are always due. Repositories that changed within the last day are
+// polled at half their configured interval.
+func ScheduleRepos(config *Config, cache *CacheData, now time.Time) ([]RepoSchedule, error) {
+	schedules := make([]RepoSchedule, 0, len(config.Repos))
+
+	for _, repoConfig := range config.Repos {
+		schedule := RepoSchedule{Repo: repoConfig, Due: true}
+
+		interval, err := repoConfig.PollInterval()
+		if err != nil {
+			return nil, err
+		}
+
+		state, exists := cache.Repos[repoConfig.Repo]
+		if interval > 0 && exists && !state.LastFetched.IsZero() {
+			if !state.LastActivity.IsZero() && now.Sub(state.LastActivity) < activeWindow {
+				interval = max(interval/2, min(interval, minAdaptiveInterval))
+			}
+
+			schedule.Interval = interval
+			schedule.LastFetched = state.LastFetched
+			schedule.NextDue = state.LastFetched.Add(interval)
+			schedule.Due = !now.Add(scheduleSlack).Before(schedule.NextDue)
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+// PollInterval returns the repository's configured polling interval, or zero to poll on every run
+func (r RepoConfig) PollInterval() (time.Duration, error) {
+	if r.Interval == "" {
+		return 0, nil
+	}
+
+	interval, err := ParseDuration(r.Interval)
+	if err != nil {
+		return 0, NewConfigError(fmt.Sprintf("invalid interval %q for %s", r.Interval, r.Repo), err)
+	}
+	if interval <= 0 {
+		return 0, NewConfigError(fmt.Sprintf("interval for %s must be positive", r.Repo), nil)
+	}
+	return interval, nil
+}
+
+// NextDue returns the earliest time a skipped repository becomes due, or the zero time if none were skipped
+func NextDue(schedules []RepoSchedule) time.Time {
+	var next time.Time
+	for _, schedule := range schedules {
+		if schedule.Due {
+			continue
+		}
+		if next.IsZero() || schedule.NextDue.Before(next) {
+			next = schedule.NextDue
+		}
+	}
+	return next
+}
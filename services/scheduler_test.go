@@ -0,0 +1,39 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-oss-watch/services"
+)
+
+func TestScheduleRepos_PollsActiveReposMoreOften(t *testing.T) {
+	now := time.Now()
+	config := &services.Config{Repos: []services.RepoConfig{
+		{Repo: "owner/active", Interval: "1h"},
+		{Repo: "owner/quiet", Interval: "1h"},
+		{Repo: "owner/new", Interval: "1h"},
+	}}
+	cache := &services.CacheData{Repos: map[string]services.RepoState{
+		"owner/active": {LastFetched: now.Add(-40 * time.Minute), LastActivity: now.Add(-40 * time.Minute)},
+		"owner/quiet":  {LastFetched: now.Add(-40 * time.Minute), LastActivity: now.Add(-48 * time.Hour)},
+	}}
+
+	schedules, err := services.ScheduleRepos(config, cache, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	due := map[string]bool{}
+	for _, schedule := range schedules {
+		due[schedule.Repo.Repo] = schedule.Due
+	}
+	if !due["owner/active"] || due["owner/quiet"] || !due["owner/new"] {
+		t.Errorf("Unexpected schedule %v", due)
+	}
+
+	config.Repos[0].Interval = "soon"
+	if _, err := services.ScheduleRepos(config, cache, now); err == nil {
+		t.Error("Expected error for invalid interval")
+	}
+}
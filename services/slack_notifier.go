@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxSlackBlocks is the Block Kit limit on blocks per message
+const maxSlackBlocks = 50
+
+// maxSlackLinks bounds how many new issues and pull requests are linked per repository
+const maxSlackLinks = 10
+
+// SlackNotifier renders changes as Block Kit messages and routes them to channels per repository or group
+type SlackNotifier struct {
+	config      SlackConfig
+	groups      *Config
+	client      *http.Client
+	retryConfig RetryConfig
+}
+
+type slackDestination struct {
+	URL     string
+	Channel string
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type   string      `json:"type"`
+	Text   *slackText  `json:"text,omitempty"`
+	Fields []slackText `json:"fields,omitempty"`
+}
+
+type slackMessage struct {
+	Channel string       `json:"channel,omitempty"`
+	Text    string       `json:"text"`
+	Blocks  []slackBlock `json:"blocks"`
+}
+
+func NewSlackNotifier(config SlackConfig, groups *Config) *SlackNotifier {
+	return &SlackNotifier{
+		config:      config,
+		groups:      groups,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		retryConfig: DefaultRetryConfig(),
+	}
+}
+
+func (s *SlackNotifier) Name() string {
+	return s.config.Name
+}
+
+func (s *SlackNotifier) Notify(ctx context.Context, run *RunResult) error {
+	var order []slackDestination
+	byDestination := map[slackDestination][]RepoRunResult{}
+
+	for _, repo := range run.Changed() {
+		destination, ok, err := s.route(repo.Repo)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if _, seen := byDestination[destination]; !seen {
+			order = append(order, destination)
+		}
+		byDestination[destination] = append(byDestination[destination], repo)
+	}
+
+	var failures []string
+	for _, destination := range order {
+		for _, message := range buildSlackMessages(destination.Channel, byDestination[destination]) {
+			body, err := json.Marshal(message)
+			if err != nil {
+				return err
+			}
+
+			err = WithRetry(ctx, s.retryConfig, func() error {
+				return postNotification(ctx, s.client, destination.URL, body, nil)
+			})
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", destination.label(), err))
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("slack %s: %s", s.config.Name, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// route picks the destination for a repository: the first matching route, otherwise the default
+func (s *SlackNotifier) route(repo string) (slackDestination, bool, error) {
+	for _, route := range s.config.Routes {
+		matched := MatchRepoPatterns(route.Repos, repo) || slices.ContainsFunc(route.Groups, func(group string) bool {
+			return s.groups.InGroup(repo, group)
+		})
+		if !matched {
+			continue
+		}
+
+		url, err := resolveSlackURL(route.WebhookURL, route.WebhookURLEnv)
+		if err != nil {
+			return slackDestination{}, false, err
+		}
+		if url == "" {
+			url, err = resolveSlackURL(s.config.WebhookURL, s.config.WebhookURLEnv)
+			if err != nil {
+				return slackDestination{}, false, err
+			}
+		}
+		if url == "" {
+			return slackDestination{}, false, NewConfigError(fmt.Sprintf("slack %s: no webhook URL for route to %s", s.config.Name, route.Channel), nil)
+		}
+		return slackDestination{URL: url, Channel: route.Channel}, true, nil
+	}
+
+	url, err := resolveSlackURL(s.config.WebhookURL, s.config.WebhookURLEnv)
+	if err != nil || url == "" {
+		return slackDestination{}, false, err
+	}
+	return slackDestination{URL: url, Channel: s.config.DefaultChannel}, true, nil
+}
+
+func resolveSlackURL(url, env string) (string, error) {
+	if url != "" {
+		return url, nil
+	}
+	if env == "" {
+		return "", nil
+	}
+	value := os.Getenv(env)
+	if value == "" {
+		return "", NewConfigError(fmt.Sprintf("environment variable %s is not set", env), nil)
+	}
+	return value, nil
+}
+
+func (d slackDestination) label() string {
+	if d.Channel != "" {
+		return d.Channel
+	}
+	return "default channel"
+}
+
+// buildSlackMessages renders one header and field section per repository, splitting at the Block Kit limit
+func buildSlackMessages(channel string, repos []RepoRunResult) []slackMessage {
+	var messages []slackMessage
+	current := slackMessage{Channel: channel}
+
+	for _, repo := range repos {
+		blocks := slackRepoBlocks(repo)
+		if len(current.Blocks)+len(blocks) > maxSlackBlocks && len(current.Blocks) > 0 {
+			messages = append(messages, current)
+			current = slackMessage{Channel: channel}
+		}
+		current.Blocks = append(current.Blocks, blocks...)
+		current.Text = appendSlackFallback(current.Text, repo)
+	}
+
+	if len(current.Blocks) > 0 {
+		messages = append(messages, current)
+	}
+	return messages
+}
+
+func slackRepoBlocks(repo RepoRunResult) []slackBlock {
+	blocks := []slackBlock{{
+		Type: "header",
+		Text: &slackText{Type: "plain_text", Text: "📈 " + repo.Repo},
+	}}
+
+	var fields []slackText
+	for _, field := range repoDeltaFields(repo) {
+		fields = append(fields, slackText{Type: "mrkdwn", Text: field})
+	}
+	if len(fields) > 0 {
+		blocks = append(blocks, slackBlock{Type: "section", Fields: fields})
+	}
+
+	var links []string
+	for _, event := range repo.Activity {
+		if event.Type != HistoryEventIssue && event.Type != HistoryEventPullRequest {
+			continue
+		}
+		if len(links) == maxSlackLinks {
+			links = append(links, fmt.Sprintf("…and more on <https://github.com/%s|GitHub>", repo.Repo))
+			break
+		}
+		icon := "🐛"
+		if event.Type == HistoryEventPullRequest {
+			icon = "🔀"
+		}
+		links = append(links, fmt.Sprintf("%s <%s|#%d %s> by @%s", icon, event.URL, event.Number, escapeSlack(event.Title), event.Author))
+	}
+	if len(links) > 0 {
+		blocks = append(blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: strings.Join(links, "\n")},
+		})
+	}
+
+	return append(blocks, slackBlock{Type: "divider"})
+}
+
+// repoDeltaFields describes each watched event that changed, e.g. "*⭐ Stars*\n+3 stars (120 total)"
+func repoDeltaFields(repo RepoRunResult) []string {
+	var fields []string
+	for _, event := range repo.Events {
+		change := repo.Change(event)
+		source, ok := LookupEventSource(event)
+		if change.Count <= 0 || !ok {
+			continue
+		}
+		fields = append(fields, fmt.Sprintf("*%s %s*\n%s", source.Emoji(), source.Title(),
+			source.Render(change, repo.CurrentState(event))))
+	}
+	return fields
+}
+
+func appendSlackFallback(text string, repo RepoRunResult) string {
+	line := repo.Repo + ": " + strings.ReplaceAll(strings.Join(repoDeltaFields(repo), ", "), "\n", " ")
+	if text == "" {
+		return line
+	}
+	return text + "\n" + line
+}
+
+func escapeSlack(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WebhookDelivery is the part of a GitHub webhook delivery that affects watched counts
+type WebhookDelivery struct {
+	Event  string
+	Action string
+	Repo   string
+
+	// Current repository counts as reported in the payload
+	Stars      int
+	Forks      int
+	OpenIssues int
+
+	// PullRequestDelta is the change in open pull requests, which payloads do not report directly
+	PullRequestDelta int
+
+	Issue   *IssueAPIData
+	Release *ReleaseAPIData
+}
+
+// Events lists the watched events a delivery updates. The open issue count GitHub
+// reports includes pull requests, so pull request deliveries update issues too.
+func (d *WebhookDelivery) Events() []string {
+	switch d.Event {
+	case "star":
+		return []string{"stars"}
+	case "fork":
+		return []string{"forks"}
+	case "issues":
+		return []string{"issues"}
+	case "pull_request":
+		return []string{"pull_requests", "issues"}
+	case "release":
+		return []string{"releases"}
+	}
+	return nil
+}
+
+type webhookPayload struct {
+	Action     string `json:"action"`
+	Repository struct {
+		FullName        string `json:"full_name"`
+		StargazersCount int    `json:"stargazers_count"`
+		ForksCount      int    `json:"forks_count"`
+		OpenIssuesCount int    `json:"open_issues_count"`
+	} `json:"repository"`
+	Issue       *IssueAPIData   `json:"issue"`
+	PullRequest *IssueAPIData   `json:"pull_request"`
+	Release     *ReleaseAPIData `json:"release"`
+}
+
+// ParseWebhookDelivery decodes a star, fork, issues, pull_request or release delivery.
+// It returns nil for other events and for actions that do not change what we watch.
+func ParseWebhookDelivery(event string, body []byte) (*WebhookDelivery, error) {
+	switch event {
+	case "star", "fork", "issues", "pull_request", "release":
+	default:
+		return nil, nil
+	}
+
+	var payload webhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, NewValidationError(fmt.Sprintf("invalid %s payload", event), "", err)
+	}
+	if payload.Repository.FullName == "" {
+		return nil, NewValidationError(fmt.Sprintf("%s payload has no repository", event), "", nil)
+	}
+
+	delivery := &WebhookDelivery{
+		Event:      event,
+		Action:     payload.Action,
+		Repo:       payload.Repository.FullName,
+		Stars:      payload.Repository.StargazersCount,
+		Forks:      payload.Repository.ForksCount,
+		OpenIssues: payload.Repository.OpenIssuesCount,
+	}
+
+	switch event {
+	case "issues":
+		if payload.Action == "opened" {
+			delivery.Issue = payload.Issue
+		}
+	case "pull_request":
+		switch payload.Action {
+		case "opened":
+			delivery.PullRequestDelta = 1
+			if payload.PullRequest != nil {
+				payload.PullRequest.PullRequest = &struct{}{}
+				delivery.Issue = payload.PullRequest
+			}
+		case "reopened":
+			delivery.PullRequestDelta = 1
+		case "closed":
+			delivery.PullRequestDelta = -1
+		}
+	case "release":
+		if payload.Action != "published" || payload.Release == nil {
+			return nil, nil
+		}
+		delivery.Release = payload.Release
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// WebhookNotifier posts a JSON payload describing the run to a configured URL
+type WebhookNotifier struct {
+	config      WebhookConfig
+	template    *template.Template
+	client      *http.Client
+	retryConfig RetryConfig
+}
+
+func NewWebhookNotifier(config WebhookConfig) (*WebhookNotifier, error) {
+	if config.URL == "" {
+		return nil, NewConfigError(fmt.Sprintf("webhook %s: url is required", config.Name), nil)
+	}
+
+	notifier := &WebhookNotifier{
+		config:      config,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		retryConfig: DefaultRetryConfig(),
+	}
+
+	if config.Template != "" {
+		tmpl, err := template.New(config.Name).Funcs(NotifierTemplateFuncs()).Parse(config.Template)
+		if err != nil {
+			return nil, NewConfigError(fmt.Sprintf("webhook %s: invalid template", config.Name), err)
+		}
+		notifier.template = tmpl
+	}
+
+	return notifier, nil
+}
+
+// NotifierTemplateFuncs returns the helpers available to notifier payload templates
+func NotifierTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"join": strings.Join,
+	}
+}
+
+func (w *WebhookNotifier) Name() string {
+	return w.config.Name
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, run *RunResult) error {
+	if !run.HasChanges() {
+		return nil
+	}
+
+	body, err := w.renderBody(run)
+	if err != nil {
+		return err
+	}
+
+	var signature string
+	if w.config.SecretEnv != "" {
+		secret := os.Getenv(w.config.SecretEnv)
+		if secret == "" {
+			return NewConfigError(fmt.Sprintf("webhook %s: environment variable %s is not set", w.config.Name, w.config.SecretEnv), nil)
+		}
+		signature = SignPayload(secret, body)
+	}
+
+	return WithRetry(ctx, w.retryConfig, func() error {
+		return w.post(ctx, body, signature)
+	})
+}
+
+func (w *WebhookNotifier) renderBody(run *RunResult) ([]byte, error) {
+	if w.template == nil {
+		return json.Marshal(run)
+	}
+
+	var buf bytes.Buffer
+	if err := w.template.Execute(&buf, run); err != nil {
+		return nil, fmt.Errorf("webhook %s: failed to render template: %w", w.config.Name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (w *WebhookNotifier) post(ctx context.Context, body []byte, signature string) error {
+	headers := map[string]string{}
+	if signature != "" {
+		headers["X-Signature-256"] = signature
+	}
+	for key, value := range w.config.Headers {
+		headers[key] = value
+	}
+
+	return postNotification(ctx, w.client, w.config.URL, body, headers)
+}
+
+// postNotification sends a JSON body and maps failures onto GitHubError types so WithRetry can classify them
+func postNotification(ctx context.Context, client *http.Client, url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return NewConfigError("invalid notification request", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gh-oss-watch")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return NewNetworkError("notification request failed", "", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return NewAPIError(fmt.Sprintf("notification endpoint returned HTTP %d", resp.StatusCode), resp.StatusCode, "", nil)
+	}
+
+	return nil
+}
+
+// SignPayload returns the "sha256=<hex>" HMAC signature of a payload
+func SignPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether a "sha256=<hex>" signature matches the payload, in constant time
+func VerifySignature(secret string, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(SignPayload(secret, payload)), []byte(signature))
+}